@@ -0,0 +1,384 @@
+package parameters
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The default settings of the arbitrary-precision getters
+const (
+	// DefaultMaxNumberDigits is the maximum number of digits (including the exponent) of arbitrary-precision
+	// numbers, unless another maximum is set with SetMaxNumberDigits
+	DefaultMaxNumberDigits = 1000
+
+	// DefaultBigFloatPrecision is the precision in bits of the values returned by GetBigFloat, unless another
+	// precision is set with SetBigFloatPrecision
+	DefaultBigFloatPrecision uint = 256
+
+	// DefaultDecimalPrecision is the maximum number of significant digits of GetDecimal
+	DefaultDecimalPrecision = 38
+
+	// DefaultDecimalScale is the maximum number of digits after the decimal point of GetDecimal
+	DefaultDecimalScale = 18
+)
+
+// DecimalLimits are the limits of decimal values, zero means no limit
+type DecimalLimits struct {
+	// Precision is the maximum number of significant digits
+	Precision int
+
+	// Scale is the maximum number of digits after the decimal point
+	Scale int
+}
+
+// decimalPattern matches a decimal number with an optional exponent (e.g. "-12.50" or "1.5e3")
+var decimalPattern = regexp.MustCompile(`^([-+]?)(\d*)(?:\.(\d*))?(?:[eE]([-+]?\d+))?$`)
+
+// Decimal is a fixed-scale decimal number: Unscaled × 10^-Scale (e.g. "12.50" is 1250 with scale 2)
+type Decimal struct {
+	Unscaled *big.Int
+	Scale    int
+}
+
+// ParseDecimal parses a decimal number of at most DefaultMaxNumberDigits, keeping the scale of the
+// value ("12.50" has scale 2)
+func ParseDecimal(s string) (Decimal, error) {
+	return parseDecimal(s, DefaultMaxNumberDigits)
+}
+
+// parseDecimal parses a decimal number of at most maxDigits
+func parseDecimal(s string, maxDigits int) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	matches := decimalPattern.FindStringSubmatch(s)
+	if matches == nil || matches[2]+matches[3] == "" {
+		return Decimal{}, fmt.Errorf("%w: decimal %q", ErrParse, s)
+	}
+	if len(s) > maxDigits {
+		return Decimal{}, fmt.Errorf("%w: decimal %q", ErrOverflow, s)
+	}
+
+	unscaled, _ := new(big.Int).SetString(matches[1]+matches[2]+matches[3], 10)
+	scale := len(matches[3])
+	if matches[4] != "" {
+		exp, err := strconv.Atoi(matches[4])
+		if err != nil || exp > maxDigits || exp < -maxDigits {
+			return Decimal{}, fmt.Errorf("%w: decimal %q", ErrOverflow, s)
+		}
+		scale -= exp
+	}
+	if scale < 0 {
+		unscaled.Mul(unscaled, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(-scale)), nil))
+		scale = 0
+	}
+	return Decimal{Unscaled: unscaled, Scale: scale}, nil
+}
+
+// String returns the decimal with all the digits of its scale (e.g. "12.50")
+func (d Decimal) String() string {
+	if d.Unscaled == nil {
+		return "0"
+	}
+	digits := new(big.Int).Abs(d.Unscaled).String()
+	sign := ""
+	if d.Unscaled.Sign() < 0 {
+		sign = "-"
+	}
+	if d.Scale <= 0 {
+		return sign + digits + strings.Repeat("0", -d.Scale)
+	}
+	if len(digits) <= d.Scale {
+		digits = strings.Repeat("0", d.Scale-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-d.Scale] + "." + digits[len(digits)-d.Scale:]
+}
+
+// Rat returns the decimal as a rational number
+func (d Decimal) Rat() *big.Rat {
+	if d.Unscaled == nil {
+		return new(big.Rat)
+	}
+	r := new(big.Rat).SetInt(d.Unscaled)
+	if d.Scale > 0 {
+		r.Quo(r, new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(d.Scale)), nil)))
+	}
+	return r
+}
+
+// Precision returns the number of significant digits
+func (d Decimal) Precision() int {
+	if d.Unscaled == nil || d.Unscaled.Sign() == 0 {
+		return 1
+	}
+	return len(new(big.Int).Abs(d.Unscaled).String())
+}
+
+// MarshalText encodes the decimal as a string
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes a decimal from a string
+func (d *Decimal) UnmarshalText(text []byte) error {
+	parsed, err := ParseDecimal(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// unmarshalJSON decodes the json data, keeping the numbers as json.Number so every digit is kept for the
+// arbitrary-precision getters (GetBigInt, GetBigRat, GetBigFloat and GetDecimal)
+func unmarshalJSON(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return errTrailingJSON
+	}
+	return nil
+}
+
+// numberText returns the digits of a numeric value, floats use the shortest representation
+func numberText(val interface{}) (string, error) {
+	switch v := val.(type) {
+	case string:
+		return strings.TrimSpace(v), nil
+	case []byte:
+		return strings.TrimSpace(string(v)), nil
+	case json.Number:
+		return v.String(), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v), nil
+	default:
+		return "", ErrWrongType
+	}
+}
+
+// toDecimal converts the value to a decimal of at most maxDigits
+func toDecimal(val interface{}, maxDigits int) (Decimal, error) {
+	text, err := numberText(val)
+	if err != nil {
+		return Decimal{}, err
+	}
+	d, err := parseDecimal(text, maxDigits)
+	if err != nil {
+		if errors.Is(err, ErrOverflow) {
+			return Decimal{}, ErrOverflow
+		}
+		return Decimal{}, ErrParse
+	}
+	return d, nil
+}
+
+// convertBigInt converts the value to an integer, fractions are rejected
+func convertBigInt(key string, val interface{}, maxDigits int) (*big.Int, error) {
+	d, err := toDecimal(val, maxDigits)
+	if err != nil {
+		return nil, newConversionError(key, val, "big.Int", err)
+	}
+	r := d.Rat()
+	if !r.IsInt() {
+		return nil, newConversionError(key, val, "big.Int", ErrParse)
+	}
+	return new(big.Int).Set(r.Num()), nil
+}
+
+// convertBigRat converts the value to a rational number, fractions like "1/3" are accepted
+func convertBigRat(key string, val interface{}, maxDigits int) (*big.Rat, error) {
+	text, err := numberText(val)
+	if err != nil {
+		return nil, newConversionError(key, val, "big.Rat", err)
+	}
+	if num, denom, found := strings.Cut(text, "/"); found {
+		if len(text) > maxDigits {
+			return nil, newConversionError(key, val, "big.Rat", ErrOverflow)
+		}
+		n, nOk := new(big.Int).SetString(num, 10)
+		dn, dOk := new(big.Int).SetString(denom, 10)
+		if !nOk || !dOk || dn.Sign() == 0 {
+			return nil, newConversionError(key, val, "big.Rat", ErrParse)
+		}
+		return new(big.Rat).SetFrac(n, dn), nil
+	}
+	d, err := toDecimal(val, maxDigits)
+	if err != nil {
+		return nil, newConversionError(key, val, "big.Rat", err)
+	}
+	return d.Rat(), nil
+}
+
+// convertDecimal converts the value to a decimal within the limits
+func convertDecimal(key string, val interface{}, limits DecimalLimits, maxDigits int) (Decimal, error) {
+	d, err := toDecimal(val, maxDigits)
+	if err != nil {
+		return Decimal{}, newConversionError(key, val, "Decimal", err)
+	}
+	if (limits.Scale > 0 && d.Scale > limits.Scale) || (limits.Precision > 0 && d.Precision() > limits.Precision) {
+		convErr := newConversionError(key, val, "Decimal", ErrOverflow)
+		convErr.Range = limits.String()
+		return Decimal{}, convErr
+	}
+	return d, nil
+}
+
+// String describes the limits (e.g. "with at most 38 digits and 18 decimal places")
+func (l DecimalLimits) String() string {
+	switch {
+	case l.Precision > 0 && l.Scale > 0:
+		return fmt.Sprintf("with at most %d digits and %d decimal places", l.Precision, l.Scale)
+	case l.Precision > 0:
+		return fmt.Sprintf("with at most %d digits", l.Precision)
+	case l.Scale > 0:
+		return fmt.Sprintf("with at most %d decimal places", l.Scale)
+	default:
+		return ""
+	}
+}
+
+// SetMaxNumberDigits sets the maximum number of digits of arbitrary-precision numbers (DefaultMaxNumberDigits if not set)
+func (p *Params) SetMaxNumberDigits(digits int) {
+	p.maxNumberDigits = digits
+}
+
+// MaxNumberDigits returns the maximum number of digits of arbitrary-precision numbers
+func (p *Params) MaxNumberDigits() int {
+	if p.maxNumberDigits <= 0 {
+		return DefaultMaxNumberDigits
+	}
+	return p.maxNumberDigits
+}
+
+// SetBigFloatPrecision sets the precision in bits of the values returned by GetBigFloat
+// (DefaultBigFloatPrecision if not set)
+func (p *Params) SetBigFloatPrecision(prec uint) {
+	p.bigFloatPrecision = prec
+}
+
+// BigFloatPrecision returns the precision in bits of the values returned by GetBigFloat
+func (p *Params) BigFloatPrecision() uint {
+	if p.bigFloatPrecision == 0 {
+		return DefaultBigFloatPrecision
+	}
+	return p.bigFloatPrecision
+}
+
+// SetDecimalLimits sets the limits of GetDecimal (DefaultDecimalPrecision and DefaultDecimalScale if not
+// set), zero limits allow any decimal
+func (p *Params) SetDecimalLimits(limits DecimalLimits) {
+	p.decimalLimits = &limits
+}
+
+// DecimalLimits returns the limits of GetDecimal
+func (p *Params) DecimalLimits() DecimalLimits {
+	if p.decimalLimits == nil {
+		return DecimalLimits{Precision: DefaultDecimalPrecision, Scale: DefaultDecimalScale}
+	}
+	return *p.decimalLimits
+}
+
+// GetBigIntE get param by key, return arbitrary-precision integer or a *ConversionError
+func (p *Params) GetBigIntE(key string) (*big.Int, error) {
+	val, err := p.getValue(key, "big.Int")
+	if err != nil {
+		return nil, err
+	}
+	return convertBigInt(key, val, p.MaxNumberDigits())
+}
+
+// GetBigIntOk get param by key, return arbitrary-precision integer
+func (p *Params) GetBigIntOk(key string) (*big.Int, bool) {
+	val, err := p.GetBigIntE(key)
+	return val, err == nil
+}
+
+// GetBigInt get param by key, return arbitrary-precision integer
+func (p *Params) GetBigInt(key string) *big.Int {
+	val, _ := p.GetBigIntOk(key)
+	return val
+}
+
+// GetBigRatE get param by key, return rational number or a *ConversionError
+func (p *Params) GetBigRatE(key string) (*big.Rat, error) {
+	val, err := p.getValue(key, "big.Rat")
+	if err != nil {
+		return nil, err
+	}
+	return convertBigRat(key, val, p.MaxNumberDigits())
+}
+
+// GetBigRatOk get param by key, return rational number
+func (p *Params) GetBigRatOk(key string) (*big.Rat, bool) {
+	val, err := p.GetBigRatE(key)
+	return val, err == nil
+}
+
+// GetBigRat get param by key, return rational number
+func (p *Params) GetBigRat(key string) *big.Rat {
+	val, _ := p.GetBigRatOk(key)
+	return val
+}
+
+// GetBigFloatE get param by key, return arbitrary-precision float (of BigFloatPrecision bits) or a *ConversionError
+func (p *Params) GetBigFloatE(key string) (*big.Float, error) {
+	val, err := p.getValue(key, "big.Float")
+	if err != nil {
+		return nil, err
+	}
+	d, err := toDecimal(val, p.MaxNumberDigits())
+	if err != nil {
+		return nil, newConversionError(key, val, "big.Float", err)
+	}
+	return new(big.Float).SetPrec(p.BigFloatPrecision()).SetRat(d.Rat()), nil
+}
+
+// GetBigFloatOk get param by key, return arbitrary-precision float
+func (p *Params) GetBigFloatOk(key string) (*big.Float, bool) {
+	val, err := p.GetBigFloatE(key)
+	return val, err == nil
+}
+
+// GetBigFloat get param by key, return arbitrary-precision float
+func (p *Params) GetBigFloat(key string) *big.Float {
+	val, _ := p.GetBigFloatOk(key)
+	return val
+}
+
+// GetDecimalWithLimitsE get param by key, return decimal within the limits or a *ConversionError
+func (p *Params) GetDecimalWithLimitsE(key string, limits DecimalLimits) (Decimal, error) {
+	val, err := p.getValue(key, "Decimal")
+	if err != nil {
+		return Decimal{}, err
+	}
+	return convertDecimal(key, val, limits, p.MaxNumberDigits())
+}
+
+// GetDecimalE get param by key, return decimal (within the DecimalLimits) or a *ConversionError
+func (p *Params) GetDecimalE(key string) (Decimal, error) {
+	return p.GetDecimalWithLimitsE(key, p.DecimalLimits())
+}
+
+// GetDecimalOk get param by key, return decimal
+func (p *Params) GetDecimalOk(key string) (Decimal, bool) {
+	val, err := p.GetDecimalE(key)
+	return val, err == nil
+}
+
+// GetDecimal get param by key, return decimal
+func (p *Params) GetDecimal(key string) Decimal {
+	val, _ := p.GetDecimalOk(key)
+	return val
+}
@@ -0,0 +1,250 @@
+package parameters
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseDecimal tests parsing decimals
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		scale    int
+		ok       bool
+	}{
+		{"12345678901234.56", "12345678901234.56", 2, true},
+		{"12.50", "12.50", 2, true},
+		{"-0.005", "-0.005", 3, true},
+		{"+7", "7", 0, true},
+		{".5", "0.5", 1, true},
+		{"5.", "5", 0, true},
+		{"1.5e3", "1500", 0, true},
+		{"15e-3", "0.015", 3, true},
+		{"1e2000", "", 0, false},
+		{"", "", 0, false},
+		{".", "", 0, false},
+		{"1,5", "", 0, false},
+		{"NaN", "", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			d, err := ParseDecimal(tt.value)
+			if !tt.ok {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, d.String())
+			assert.Equal(t, tt.scale, d.Scale)
+		})
+	}
+
+	assert.Equal(t, "0", Decimal{}.String())
+	d, err := ParseDecimal("0.25")
+	require.NoError(t, err)
+	assert.Equal(t, "1/4", d.Rat().String())
+	assert.Equal(t, 2, d.Precision())
+}
+
+// TestDecimal_Text tests the text encoding of a decimal
+func TestDecimal_Text(t *testing.T) {
+	var d Decimal
+	require.NoError(t, d.UnmarshalText([]byte("99.90")))
+	text, err := d.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "99.90", string(text))
+	require.ErrorIs(t, d.UnmarshalText([]byte("abc")), ErrParse)
+}
+
+// TestParams_GetBigInt tests the arbitrary-precision integer getters
+func TestParams_GetBigInt(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"balance":  "123456789012345678901234567890",
+		"number":   json.Number("98765432109876543210"),
+		"float":    float64(1 << 40),
+		"int":      uint64(18446744073709551615),
+		"exponent": "1e21",
+		"fraction": "1.5",
+		"text":     "abc",
+		"bool":     true,
+	}}
+
+	assert.Equal(t, "123456789012345678901234567890", params.GetBigInt("balance").String())
+	assert.Equal(t, "98765432109876543210", params.GetBigInt("number").String())
+	assert.Equal(t, "1099511627776", params.GetBigInt("float").String())
+	assert.Equal(t, "18446744073709551615", params.GetBigInt("int").String())
+	assert.Equal(t, "1000000000000000000000", params.GetBigInt("exponent").String())
+
+	_, err := params.GetBigIntE("fraction")
+	require.ErrorIs(t, err, ErrParse)
+	assert.Equal(t, "fraction must be an integer", err.Error())
+
+	_, err = params.GetBigIntE("text")
+	require.ErrorIs(t, err, ErrParse)
+
+	_, err = params.GetBigIntE("bool")
+	require.ErrorIs(t, err, ErrWrongType)
+
+	_, ok := params.GetBigIntOk("missing")
+	assert.False(t, ok)
+	assert.Nil(t, params.GetBigInt("missing"))
+}
+
+// TestParams_GetBigRat tests the rational number getters
+func TestParams_GetBigRat(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"price":    "12345678901234.56",
+		"third":    "1/3",
+		"zero":     "1/0",
+		"float":    0.1,
+		"number":   json.Number("0.125"),
+		"exponent": "1e-2",
+	}}
+
+	assert.Equal(t, "12345678901234.56", params.GetBigRat("price").FloatString(2))
+	assert.Equal(t, "1/3", params.GetBigRat("third").String())
+	assert.Equal(t, "1/10", params.GetBigRat("float").String())
+	assert.Equal(t, "1/8", params.GetBigRat("number").String())
+	assert.Equal(t, "1/100", params.GetBigRat("exponent").String())
+
+	_, err := params.GetBigRatE("zero")
+	require.ErrorIs(t, err, ErrParse)
+}
+
+// TestParams_GetBigFloat tests the arbitrary-precision float getters
+func TestParams_GetBigFloat(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{"amount": "12345678901234.56", "text": "abc"}}
+
+	f := params.GetBigFloat("amount")
+	require.NotNil(t, f)
+	assert.Equal(t, DefaultBigFloatPrecision, f.Prec())
+	assert.Equal(t, "12345678901234.56", f.Text('f', 2))
+
+	_, ok := params.GetBigFloatOk("text")
+	assert.False(t, ok)
+}
+
+// TestParams_GetDecimal tests the decimal getters with limits
+func TestParams_GetDecimal(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"price":   "12345678901234.56",
+		"precise": "1.123",
+		"float":   19.99,
+		"long":    "123456",
+		"text":    "abc",
+	}}
+
+	assert.Equal(t, "12345678901234.56", params.GetDecimal("price").String())
+	assert.Equal(t, "19.99", params.GetDecimal("float").String())
+
+	limits := DecimalLimits{Precision: 5, Scale: 2}
+	_, err := params.GetDecimalWithLimitsE("precise", limits)
+	require.ErrorIs(t, err, ErrOverflow)
+	assert.Equal(t, "precise must be a decimal number with at most 5 digits and 2 decimal places", err.Error())
+
+	_, err = params.GetDecimalWithLimitsE("long", limits)
+	require.ErrorIs(t, err, ErrOverflow)
+
+	d, err := params.GetDecimalWithLimitsE("float", limits)
+	require.NoError(t, err)
+	assert.Equal(t, 2, d.Scale)
+
+	_, err = params.GetDecimalE("text")
+	require.ErrorIs(t, err, ErrParse)
+	assert.Equal(t, "text must be a decimal number", err.Error())
+}
+
+// TestParams_BigNumberSettings tests that the arbitrary-precision settings are kept per params
+func TestParams_BigNumberSettings(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"long":   strings.Repeat("9", 30),
+		"amount": "1.5",
+		"price":  "0.1234567890123456789",
+		"nested": map[string]interface{}{"long": strings.Repeat("9", 30)},
+	}}
+	assert.Equal(t, DefaultMaxNumberDigits, params.MaxNumberDigits())
+	assert.Equal(t, DefaultBigFloatPrecision, params.BigFloatPrecision())
+	assert.Equal(t, DecimalLimits{Precision: DefaultDecimalPrecision, Scale: DefaultDecimalScale}, params.DecimalLimits())
+	_, err := params.GetDecimalE("price")
+	require.ErrorIs(t, err, ErrOverflow)
+
+	params.SetMaxNumberDigits(20)
+	params.SetBigFloatPrecision(64)
+	params.SetDecimalLimits(DecimalLimits{})
+
+	_, err = params.GetBigIntE("long")
+	require.ErrorIs(t, err, ErrOverflow)
+	_, err = params.GetBigRatE("long")
+	require.ErrorIs(t, err, ErrOverflow)
+	assert.Equal(t, uint(64), params.GetBigFloat("amount").Prec())
+	_, err = params.GetDecimalE("price")
+	require.ErrorIs(t, err, ErrOverflow)
+
+	params.SetMaxNumberDigits(0)
+	assert.Equal(t, "0.1234567890123456789", params.GetDecimal("price").String())
+
+	// The settings are kept by the copies and nested params
+	params.SetMaxNumberDigits(20)
+	for _, copied := range []*Params{params.Clone(), params.DeepClone(), params.Sub("nested")} {
+		_, err = copied.GetBigIntE("long")
+		require.ErrorIs(t, err, ErrOverflow)
+		assert.Equal(t, uint(64), copied.BigFloatPrecision())
+		assert.Equal(t, DecimalLimits{}, copied.DecimalLimits())
+	}
+
+	// Other params keep the defaults
+	other := &Params{Values: map[string]interface{}{"long": strings.Repeat("9", 30)}}
+	assert.Equal(t, strings.Repeat("9", 30), other.GetBigInt("long").String())
+}
+
+// TestParseParams_JSONNumbers tests decoding json numbers without losing precision
+func TestParseParams_JSONNumbers(t *testing.T) {
+	body := []byte(`{"balance": 12345678901234567890.123456789, "id": 12345678901234567890, "count": 3, "ratio": 0.5}`)
+
+	params := ParseParams(newLimitsRequest(t, "/", "application/json", body))
+	assert.Equal(t, "12345678901234567890.123456789", params.GetDecimal("balance").String())
+	assert.Equal(t, "12345678901234567890", params.GetBigInt("id").String())
+	assert.Equal(t, uint64(12345678901234567890), params.GetUint64("id"))
+	assert.Equal(t, 3, params.GetInt("count"))
+	assert.InDelta(t, 0.5, params.GetFloat("ratio"), 0)
+
+	params, err := ParseParamsWithLimits(newLimitsRequest(t, "/", "application/json", body), Limits{MaxKeys: 10})
+	require.NoError(t, err)
+	assert.Equal(t, json.Number("12345678901234567890.123456789"), params.Values["balance"])
+	assert.Equal(t, "12345678901234567890", params.GetBigInt("id").String())
+}
+
+// TestImbue_BigNumbers tests that Imbue sets the arbitrary-precision fields
+func TestImbue_BigNumbers(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"supply":  "123456789012345678901234567890",
+		"ratio":   "2/3",
+		"amount":  "1.5",
+		"price":   "9.99",
+		"nothing": "abc",
+	}}
+
+	type testType struct {
+		Supply  *big.Int
+		Ratio   *big.Rat
+		Amount  *big.Float
+		Price   Decimal
+		Nothing *big.Int
+	}
+
+	var obj testType
+	params.Imbue(&obj)
+
+	require.NotNil(t, obj.Supply)
+	assert.Equal(t, "123456789012345678901234567890", obj.Supply.String())
+	assert.Equal(t, "2/3", obj.Ratio.String())
+	assert.Equal(t, "1.5", obj.Amount.Text('f', 1))
+	assert.Equal(t, "9.99", obj.Price.String())
+	assert.Nil(t, obj.Nothing)
+}
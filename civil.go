@@ -0,0 +1,290 @@
+package parameters
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // Embed the IANA time zone database for GetLocation
+)
+
+// Layouts of the civil types (the values of the HTML input types date, time, month and week)
+const (
+	// MonthOnly is the year and month
+	MonthOnly = "2006-01"
+
+	// TimeOfDayLayout is the time of day with optional seconds
+	TimeOfDayLayout = "15:04"
+)
+
+// Date is a calendar date without a time or time zone (e.g. "2024-05-01")
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date of the time
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// ParseDate parses a date in the DateOnly layout
+func ParseDate(s string) (Date, error) {
+	t, err := time.Parse(DateOnly, strings.TrimSpace(s))
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t), nil
+}
+
+// String returns the date in the DateOnly layout
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// IsZero returns true if the date is not set
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// In returns the start of the date in the location
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// TimeOfDay is a time of day without a date or time zone (e.g. "14:30")
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+// ParseTimeOfDay parses a time of day as "15:04", "15:04:05" or with fractional seconds
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	s = strings.TrimSpace(s)
+	layout := TimeOfDayLayout
+	if strings.Count(s, ":") == 2 {
+		layout = time.TimeOnly
+	}
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return TimeOfDay{}, err
+	}
+	return TimeOfDay{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second(), Nanosecond: t.Nanosecond()}, nil
+}
+
+// String returns the time of day as "15:04", with seconds and fractional seconds if not zero
+func (t TimeOfDay) String() string {
+	s := fmt.Sprintf("%02d:%02d", t.Hour, t.Minute)
+	if t.Second != 0 || t.Nanosecond != 0 {
+		s += fmt.Sprintf(":%02d", t.Second)
+	}
+	if t.Nanosecond != 0 {
+		s += strings.TrimRight(fmt.Sprintf(".%09d", t.Nanosecond), "0")
+	}
+	return s
+}
+
+// On returns the time of day on the date in the location
+func (t TimeOfDay) On(d Date, loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, t.Hour, t.Minute, t.Second, t.Nanosecond, loc)
+}
+
+// YearMonth is a month of a year (e.g. "2024-05")
+type YearMonth struct {
+	Year  int
+	Month time.Month
+}
+
+// ParseYearMonth parses a month in the MonthOnly layout
+func ParseYearMonth(s string) (YearMonth, error) {
+	t, err := time.Parse(MonthOnly, strings.TrimSpace(s))
+	if err != nil {
+		return YearMonth{}, err
+	}
+	return YearMonth{Year: t.Year(), Month: t.Month()}, nil
+}
+
+// String returns the month in the MonthOnly layout
+func (m YearMonth) String() string {
+	return fmt.Sprintf("%04d-%02d", m.Year, m.Month)
+}
+
+// FirstDay returns the first day of the month
+func (m YearMonth) FirstDay() Date {
+	return Date{Year: m.Year, Month: m.Month, Day: 1}
+}
+
+// ISOWeek is an ISO 8601 week of a year (e.g. "2024-W18")
+type ISOWeek struct {
+	Year int
+	Week int
+}
+
+// ParseISOWeek parses a week as "2024-W18"
+func ParseISOWeek(s string) (ISOWeek, error) {
+	yearPart, weekPart, found := strings.Cut(strings.ToUpper(strings.TrimSpace(s)), "-W")
+	if !found || len(yearPart) != 4 || len(weekPart) != 2 || strings.Trim(yearPart+weekPart, "0123456789") != "" {
+		return ISOWeek{}, fmt.Errorf("%w: week %q", ErrParse, s)
+	}
+	year, err := strconv.Atoi(yearPart)
+	if err != nil {
+		return ISOWeek{}, fmt.Errorf("%w: week %q", ErrParse, s)
+	}
+	week, err := strconv.Atoi(weekPart)
+	if err != nil || week < 1 || week > weeksInYear(year) {
+		return ISOWeek{}, fmt.Errorf("%w: week %q", ErrParse, s)
+	}
+	return ISOWeek{Year: year, Week: week}, nil
+}
+
+// weeksInYear returns the number of ISO weeks in the year (52 or 53)
+func weeksInYear(year int) int {
+	_, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return week
+}
+
+// String returns the week as "2024-W18"
+func (w ISOWeek) String() string {
+	return fmt.Sprintf("%04d-W%02d", w.Year, w.Week)
+}
+
+// Monday returns the first day of the week
+func (w ISOWeek) Monday() Date {
+	// January 4th is always in the first week
+	jan4 := time.Date(w.Year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	offset := (int(jan4.Weekday()) + 6) % 7
+	return DateOf(jan4.AddDate(0, 0, (w.Week-1)*7-offset))
+}
+
+// convertCivil converts a string (or a time) value using the parser
+func convertCivil[T any](p *Params, key, target string, parse func(string) (T, error), fromTime func(time.Time) T) (T, error) {
+	var zero T
+	val, err := p.getValue(key, target)
+	if err != nil {
+		return zero, err
+	}
+	switch v := val.(type) {
+	case string:
+		parsed, parseErr := parse(v)
+		if parseErr != nil {
+			return zero, newConversionError(key, val, target, ErrParse)
+		}
+		return parsed, nil
+	case []byte:
+		parsed, parseErr := parse(string(v))
+		if parseErr != nil {
+			return zero, newConversionError(key, val, target, ErrParse)
+		}
+		return parsed, nil
+	case time.Time:
+		if fromTime != nil {
+			return fromTime(v.In(p.Location())), nil
+		}
+	}
+	return zero, newConversionError(key, val, target, ErrWrongType)
+}
+
+// GetDateE get param by key, return date or a *ConversionError
+func (p *Params) GetDateE(key string) (Date, error) {
+	return convertCivil(p, key, "Date", ParseDate, DateOf)
+}
+
+// GetDateOk get param by key, return date
+func (p *Params) GetDateOk(key string) (Date, bool) {
+	val, err := p.GetDateE(key)
+	return val, err == nil
+}
+
+// GetDate get param by key, return date
+func (p *Params) GetDate(key string) Date {
+	val, _ := p.GetDateOk(key)
+	return val
+}
+
+// GetTimeOfDayE get param by key, return time of day or a *ConversionError
+func (p *Params) GetTimeOfDayE(key string) (TimeOfDay, error) {
+	return convertCivil(p, key, "TimeOfDay", ParseTimeOfDay, func(t time.Time) TimeOfDay {
+		return TimeOfDay{Hour: t.Hour(), Minute: t.Minute(), Second: t.Second(), Nanosecond: t.Nanosecond()}
+	})
+}
+
+// GetTimeOfDayOk get param by key, return time of day
+func (p *Params) GetTimeOfDayOk(key string) (TimeOfDay, bool) {
+	val, err := p.GetTimeOfDayE(key)
+	return val, err == nil
+}
+
+// GetTimeOfDay get param by key, return time of day
+func (p *Params) GetTimeOfDay(key string) TimeOfDay {
+	val, _ := p.GetTimeOfDayOk(key)
+	return val
+}
+
+// GetMonthE get param by key, return month or a *ConversionError
+func (p *Params) GetMonthE(key string) (YearMonth, error) {
+	return convertCivil(p, key, "YearMonth", ParseYearMonth, func(t time.Time) YearMonth {
+		return YearMonth{Year: t.Year(), Month: t.Month()}
+	})
+}
+
+// GetMonthOk get param by key, return month
+func (p *Params) GetMonthOk(key string) (YearMonth, bool) {
+	val, err := p.GetMonthE(key)
+	return val, err == nil
+}
+
+// GetMonth get param by key, return month
+func (p *Params) GetMonth(key string) YearMonth {
+	val, _ := p.GetMonthOk(key)
+	return val
+}
+
+// GetISOWeekE get param by key, return week or a *ConversionError
+func (p *Params) GetISOWeekE(key string) (ISOWeek, error) {
+	return convertCivil(p, key, "ISOWeek", ParseISOWeek, func(t time.Time) ISOWeek {
+		year, week := t.ISOWeek()
+		return ISOWeek{Year: year, Week: week}
+	})
+}
+
+// GetISOWeekOk get param by key, return week
+func (p *Params) GetISOWeekOk(key string) (ISOWeek, bool) {
+	val, err := p.GetISOWeekE(key)
+	return val, err == nil
+}
+
+// GetISOWeek get param by key, return week
+func (p *Params) GetISOWeek(key string) ISOWeek {
+	val, _ := p.GetISOWeekOk(key)
+	return val
+}
+
+// loadLocation loads the IANA time zone, rejecting "Local" and empty names
+func loadLocation(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("%w: time zone %q", ErrParse, name)
+	}
+	return time.LoadLocation(name)
+}
+
+// GetLocationE get param by key, return the IANA time zone (e.g. "Europe/Amsterdam") or a *ConversionError
+func (p *Params) GetLocationE(key string) (*time.Location, error) {
+	return convertCivil(p, key, "time.Location", loadLocation, nil)
+}
+
+// GetLocationOk get param by key, return the IANA time zone
+func (p *Params) GetLocationOk(key string) (*time.Location, bool) {
+	val, err := p.GetLocationE(key)
+	return val, err == nil
+}
+
+// GetLocation get param by key, return the IANA time zone
+func (p *Params) GetLocation(key string) *time.Location {
+	val, _ := p.GetLocationOk(key)
+	return val
+}
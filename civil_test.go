@@ -0,0 +1,171 @@
+package parameters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParams_GetDate tests the date getters
+func TestParams_GetDate(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"birthday": "2024-05-01",
+		"invalid":  "2024-02-30",
+		"time":     time.Date(2024, 5, 1, 23, 0, 0, 0, time.UTC),
+		"number":   20240501,
+	}}
+
+	date, err := params.GetDateE("birthday")
+	require.NoError(t, err)
+	assert.Equal(t, Date{Year: 2024, Month: time.May, Day: 1}, date)
+	assert.Equal(t, "2024-05-01", date.String())
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), date.In(time.UTC))
+
+	_, err = params.GetDateE("invalid")
+	require.ErrorIs(t, err, ErrParse)
+	assert.Equal(t, "invalid must be a date (YYYY-MM-DD)", err.Error())
+
+	_, err = params.GetDateE("number")
+	require.ErrorIs(t, err, ErrWrongType)
+
+	params.SetLocation(time.FixedZone("UTC+2", 2*60*60))
+	assert.Equal(t, Date{Year: 2024, Month: time.May, Day: 2}, params.GetDate("time"))
+
+	_, ok := params.GetDateOk("missing")
+	assert.False(t, ok)
+	assert.True(t, params.GetDate("missing").IsZero())
+}
+
+// TestParams_GetTimeOfDay tests the time of day getters
+func TestParams_GetTimeOfDay(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected TimeOfDay
+		str      string
+		ok       bool
+	}{
+		{"14:30", TimeOfDay{Hour: 14, Minute: 30}, "14:30", true},
+		{"14:30:15", TimeOfDay{Hour: 14, Minute: 30, Second: 15}, "14:30:15", true},
+		{"14:30:15.25", TimeOfDay{Hour: 14, Minute: 30, Second: 15, Nanosecond: 250000000}, "14:30:15.25", true},
+		{"24:00", TimeOfDay{}, "", false},
+		{"2pm", TimeOfDay{}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			params := &Params{Values: map[string]interface{}{"at": tt.value}}
+			tod, ok := params.GetTimeOfDayOk("at")
+			require.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, tod)
+			if tt.ok {
+				assert.Equal(t, tt.str, tod.String())
+			}
+		})
+	}
+
+	tod := TimeOfDay{Hour: 9, Minute: 15}
+	date := Date{Year: 2024, Month: time.May, Day: 1}
+	assert.Equal(t, time.Date(2024, 5, 1, 9, 15, 0, 0, time.UTC), tod.On(date, time.UTC))
+}
+
+// TestParams_GetMonth tests the month getters
+func TestParams_GetMonth(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{"period": "2024-05", "invalid": "2024-13"}}
+
+	month := params.GetMonth("period")
+	assert.Equal(t, YearMonth{Year: 2024, Month: time.May}, month)
+	assert.Equal(t, "2024-05", month.String())
+	assert.Equal(t, Date{Year: 2024, Month: time.May, Day: 1}, month.FirstDay())
+
+	_, err := params.GetMonthE("invalid")
+	require.ErrorIs(t, err, ErrParse)
+}
+
+// TestParams_GetISOWeek tests the week getters
+func TestParams_GetISOWeek(t *testing.T) {
+	tests := []struct {
+		value  string
+		week   ISOWeek
+		monday Date
+		ok     bool
+	}{
+		{"2024-W18", ISOWeek{Year: 2024, Week: 18}, Date{Year: 2024, Month: time.April, Day: 29}, true},
+		{"2024-w01", ISOWeek{Year: 2024, Week: 1}, Date{Year: 2024, Month: time.January, Day: 1}, true},
+		{"2020-W53", ISOWeek{Year: 2020, Week: 53}, Date{Year: 2020, Month: time.December, Day: 28}, true},
+		{"2021-W01", ISOWeek{Year: 2021, Week: 1}, Date{Year: 2021, Month: time.January, Day: 4}, true},
+		{"2024-W53", ISOWeek{}, Date{}, false},
+		{"2024-W00", ISOWeek{}, Date{}, false},
+		{"2024-18", ISOWeek{}, Date{}, false},
+		{"2024-W+1", ISOWeek{}, Date{}, false},
+		{"+123-W05", ISOWeek{}, Date{}, false},
+		{"-123-W05", ISOWeek{}, Date{}, false},
+		{"2024-W-1", ISOWeek{}, Date{}, false},
+		{"2024-W 5", ISOWeek{}, Date{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			params := &Params{Values: map[string]interface{}{"week": tt.value}}
+			week, ok := params.GetISOWeekOk("week")
+			require.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.week, week)
+			if tt.ok {
+				assert.Equal(t, tt.monday, week.Monday())
+				assert.Equal(t, tt.value[:5]+"W"+tt.value[6:], week.String())
+			}
+		})
+	}
+}
+
+// TestParams_GetLocation tests the time zone getters
+func TestParams_GetLocation(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"tz":      "Europe/Amsterdam",
+		"utc":     "UTC",
+		"local":   "Local",
+		"invalid": "Mars/Olympus_Mons",
+	}}
+
+	loc := params.GetLocation("tz")
+	require.NotNil(t, loc)
+	assert.Equal(t, "Europe/Amsterdam", loc.String())
+	assert.Equal(t, time.UTC, params.GetLocation("utc"))
+
+	_, err := params.GetLocationE("local")
+	require.ErrorIs(t, err, ErrParse)
+
+	_, err = params.GetLocationE("invalid")
+	require.ErrorIs(t, err, ErrParse)
+	assert.Equal(t, "invalid must be an IANA time zone", err.Error())
+
+	assert.Nil(t, params.GetLocation("missing"))
+}
+
+// TestImbue_Civil tests that Imbue sets the civil type fields
+func TestImbue_Civil(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"start_date": "2024-05-01",
+		"start_time": "09:30",
+		"period":     "2024-05",
+		"week":       "2024-W18",
+		"time_zone":  "America/New_York",
+	}}
+
+	type testType struct {
+		StartDate Date
+		StartTime TimeOfDay
+		Period    YearMonth
+		Week      ISOWeek
+		TimeZone  *time.Location
+	}
+
+	var obj testType
+	params.Imbue(&obj)
+
+	assert.Equal(t, "2024-05-01", obj.StartDate.String())
+	assert.Equal(t, "09:30", obj.StartTime.String())
+	assert.Equal(t, "2024-05", obj.Period.String())
+	assert.Equal(t, "2024-W18", obj.Week.String())
+	require.NotNil(t, obj.TimeZone)
+	assert.Equal(t, "America/New_York", obj.TimeZone.String())
+}
@@ -0,0 +1,623 @@
+package parameters
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Reasons a value cannot be converted, wrapped by ConversionError (missing keys use ErrParamNotFound)
+var (
+	// ErrNullValue is the reason when the value is null
+	ErrNullValue = errors.New("parameters: value is null")
+
+	// ErrWrongType is the reason when the value is of a type that cannot be converted
+	ErrWrongType = errors.New("parameters: wrong type")
+
+	// ErrOverflow is the reason when the value is out of the range of the target type
+	ErrOverflow = errors.New("parameters: value out of range")
+
+	// ErrParse is the reason when the value cannot be parsed
+	ErrParse = errors.New("parameters: invalid format")
+)
+
+// The syntax of numeric text, strconv.ParseFloat also accepts Go syntax ("1_000", "0x1p4") that is not a
+// valid parameter, exponents are only valid in JSON numbers
+var (
+	plainNumberPattern = regexp.MustCompile(`^[-+]?\d+(?:\.\d+)?$`)
+	jsonNumberPattern  = regexp.MustCompile(`^-?\d+(?:\.\d+)?(?:[eE][-+]?\d+)?$`)
+)
+
+// targetDescriptions describe the target types in error messages
+var targetDescriptions = map[string]string{
+	"bool":                "a boolean",
+	"float32":             "a number",
+	"float64":             "a number",
+	"int":                 "an integer",
+	"int8":                "an integer",
+	"int16":               "an integer",
+	"int32":               "an integer",
+	"int64":               "an integer",
+	"uint":                "a non-negative integer",
+	"uint8":               "a non-negative integer",
+	"uint16":              "a non-negative integer",
+	"uint32":              "a non-negative integer",
+	"uint64":              "a non-negative integer",
+	"string":              "a string",
+	"[]byte":              "base64 encoded data",
+	"time.Time":           "a time",
+	"time.Duration":       "a duration",
+	"time.Location":       "an IANA time zone",
+	"Date":                "a date (YYYY-MM-DD)",
+	"TimeOfDay":           "a time of day (HH:MM)",
+	"YearMonth":           "a month (YYYY-MM)",
+	"ISOWeek":             "a week (YYYY-Www)",
+	"UUID":                "a UUID",
+	"big.Int":             "an integer",
+	"big.Rat":             "a number",
+	"big.Float":           "a number",
+	"Decimal":             "a decimal number",
+	"IP address":          "an IP address",
+	"IP prefix":           "an IP prefix (CIDR)",
+	"IP address and port": "an IP address and port",
+	"URL":                 "an absolute URL",
+	"relative URL":        "a relative URL",
+}
+
+// ConversionError is returned by the error returning getters when the value cannot be converted
+type ConversionError struct {
+	// Key is the key of the value (with the index for slice elements, e.g. "ids.2")
+	Key string
+
+	// Value is the source value (nil if missing)
+	Value interface{}
+
+	// Target is the name of the target type (e.g. "int32")
+	Target string
+
+	// Reason is why the conversion failed: ErrParamNotFound, ErrNullValue, ErrWrongType, ErrOverflow, ErrParse
+	// or a reason of the target type (e.g. ErrEnumValue)
+	Reason error
+
+	// Range is the allowed range of the target type (e.g. "between 0 and 255"), set for ErrOverflow
+	Range string
+
+	// Allowed are the permitted values, set for ErrEnumValue
+	Allowed []string
+}
+
+// Error returns a message that can be shown to API clients, e.g. "page must be an integer between 1 and 100"
+func (e *ConversionError) Error() string {
+	switch {
+	case errors.Is(e.Reason, ErrParamNotFound):
+		return e.Key + " is required"
+	case errors.Is(e.Reason, ErrNullValue):
+		return e.Key + " must not be null"
+	case errors.Is(e.Reason, ErrOverflow) && e.Range != "":
+		return e.Key + " must be " + describeTarget(e.Target) + " " + e.Range
+	case len(e.Allowed) > 0:
+		return e.Key + " must be one of: " + strings.Join(e.Allowed, ", ")
+	default:
+		return e.Key + " must be " + describeTarget(e.Target)
+	}
+}
+
+// Unwrap returns the reason and ErrConversionFailed
+func (e *ConversionError) Unwrap() []error {
+	return []error{e.Reason, ErrConversionFailed}
+}
+
+// describeTarget describes the target type
+func describeTarget(target string) string {
+	if desc, ok := targetDescriptions[target]; ok {
+		return desc
+	}
+	if format, ok := lookupFormat(target); ok && format.description != "" {
+		return format.description
+	}
+	if elem, ok := strings.CutPrefix(target, "[]"); ok {
+		return "a list of " + strings.TrimPrefix(strings.TrimPrefix(describeTarget(elem), "a "), "an ") + " values"
+	}
+	return "a valid " + target
+}
+
+// newConversionError creates the error for the value
+func newConversionError(key string, value interface{}, target string, reason error) *ConversionError {
+	return &ConversionError{Key: key, Value: value, Target: target, Reason: reason}
+}
+
+// withRange sets the range of the error
+func (e *ConversionError) withRange(minimum, maximum interface{}) *ConversionError {
+	e.Range = fmt.Sprintf("between %v and %v", minimum, maximum)
+	return e
+}
+
+// getValue returns the value of the key or a ConversionError if it is missing or null
+func (p *Params) getValue(key, target string) (interface{}, error) {
+	val, ok := p.Get(key)
+	if !ok {
+		return nil, newConversionError(key, nil, target, ErrParamNotFound)
+	}
+	if val == nil {
+		return nil, newConversionError(key, nil, target, ErrNullValue)
+	}
+	return val, nil
+}
+
+// parseReason returns ErrOverflow for range errors and ErrParse for other strconv errors
+func parseReason(err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return ErrOverflow
+	}
+	return ErrParse
+}
+
+// numberString returns the text of string, []byte and json.Number values without surrounding whitespace
+func numberString(val interface{}) (string, bool) {
+	switch v := val.(type) {
+	case string:
+		return strings.TrimSpace(v), true
+	case []byte:
+		return strings.TrimSpace(string(v)), true
+	case json.Number:
+		return strings.TrimSpace(string(v)), true
+	default:
+		return "", false
+	}
+}
+
+// numberPattern returns the syntax of the numeric text of the value
+func numberPattern(val interface{}) *regexp.Regexp {
+	if _, ok := val.(json.Number); ok {
+		return jsonNumberPattern
+	}
+	return plainNumberPattern
+}
+
+// parseNumber parses the text as an int64, an uint64 or a float64 (in that order), so numeric
+// text converts with the same rules as the numeric value it represents ("2.0" like 2.0), the
+// text must match the pattern to be parsed as a float
+func parseNumber(s string, pattern *regexp.Regexp) (interface{}, error) {
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err == nil {
+		return i, nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		if u, uErr := strconv.ParseUint(s, 10, 64); uErr == nil {
+			return u, nil
+		}
+		return nil, ErrOverflow
+	}
+	if !pattern.MatchString(s) {
+		return nil, ErrParse
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, parseReason(err)
+	}
+	return f, nil
+}
+
+// toInt64 converts the value to an int64, floats must be integers within the safe integer range
+func toInt64(val interface{}) (int64, error) {
+	if s, ok := numberString(val); ok {
+		n, err := parseNumber(s, numberPattern(val))
+		if err != nil {
+			return 0, err
+		}
+		return toInt64(n)
+	}
+	switch v := val.(type) {
+	case int:
+		return int64(v), nil
+	case int8, int16, int32, int64:
+		return reflect.ValueOf(v).Int(), nil
+	case uint, uint8, uint16, uint32, uint64:
+		u := reflect.ValueOf(v).Uint()
+		if u > math.MaxInt64 {
+			return 0, ErrOverflow
+		}
+		return int64(u), nil
+	case float32, float64:
+		f := reflect.ValueOf(v).Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) || f != math.Trunc(f) {
+			return 0, ErrParse
+		}
+		if f > MaxSafeInt || f < -MaxSafeInt {
+			return 0, ErrOverflow
+		}
+		return int64(f), nil
+	default:
+		return 0, ErrWrongType
+	}
+}
+
+// toUint64 converts the value to an uint64, negative values are out of range
+func toUint64(val interface{}) (uint64, error) {
+	if s, ok := numberString(val); ok {
+		n, err := parseNumber(s, numberPattern(val))
+		if err != nil {
+			return 0, err
+		}
+		return toUint64(n)
+	}
+	switch v := val.(type) {
+	case uint, uint8, uint16, uint32, uint64:
+		return reflect.ValueOf(v).Uint(), nil
+	default:
+		i, err := toInt64(v)
+		if err != nil {
+			return 0, err
+		}
+		if i < 0 {
+			return 0, ErrOverflow
+		}
+		return uint64(i), nil
+	}
+}
+
+// toFloat64 converts the value to a float64, NaN and Infinity are rejected
+func toFloat64(val interface{}) (float64, error) {
+	var f float64
+	if s, ok := numberString(val); ok {
+		parsed, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, parseReason(err)
+		}
+		f = parsed
+	} else {
+		switch v := val.(type) {
+		case float32, float64:
+			f = reflect.ValueOf(v).Float()
+		case int, int8, int16, int32, int64:
+			f = float64(reflect.ValueOf(v).Int())
+		case uint, uint8, uint16, uint32, uint64:
+			f = float64(reflect.ValueOf(v).Uint())
+		default:
+			return 0, ErrWrongType
+		}
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, ErrParse
+	}
+	return f, nil
+}
+
+// toBool converts the value to a bool, numbers (and numeric text) are true when not zero
+func toBool(val interface{}) (bool, error) {
+	if b, ok := val.(bool); ok {
+		return b, nil
+	}
+	if s, ok := numberString(val); ok {
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, nil
+		}
+	}
+	i, err := toInt64(val)
+	if err != nil {
+		return false, err
+	}
+	return i != 0, nil
+}
+
+// getSigned returns the value as a signed integer within the range of the target type
+func (p *Params) getSigned(key, target string, minimum, maximum int64) (int64, error) {
+	val, err := p.getValue(key, target)
+	if err != nil {
+		return 0, err
+	}
+	return convertSigned(key, val, target, minimum, maximum)
+}
+
+// convertSigned converts the value to a signed integer within the range of the target type
+func convertSigned(key string, val interface{}, target string, minimum, maximum int64) (int64, error) {
+	i, err := toInt64(val)
+	if err == nil && (i < minimum || i > maximum) {
+		err = ErrOverflow
+	}
+	if err != nil {
+		convErr := newConversionError(key, val, target, err)
+		if errors.Is(err, ErrOverflow) {
+			convErr.withRange(minimum, maximum)
+		}
+		return 0, convErr
+	}
+	return i, nil
+}
+
+// getUnsigned returns the value as an unsigned integer within the range of the target type
+func (p *Params) getUnsigned(key, target string, maximum uint64) (uint64, error) {
+	val, err := p.getValue(key, target)
+	if err != nil {
+		return 0, err
+	}
+	return convertUnsigned(key, val, target, maximum)
+}
+
+// convertUnsigned converts the value to an unsigned integer within the range of the target type
+func convertUnsigned(key string, val interface{}, target string, maximum uint64) (uint64, error) {
+	u, err := toUint64(val)
+	if err == nil && u > maximum {
+		err = ErrOverflow
+	}
+	if err != nil {
+		convErr := newConversionError(key, val, target, err)
+		if errors.Is(err, ErrOverflow) {
+			convErr.withRange(0, maximum)
+		}
+		return 0, convErr
+	}
+	return u, nil
+}
+
+// convertFloat converts the value to a float within the range of the target type
+func convertFloat(key string, val interface{}, target string, maximum float64) (float64, error) {
+	f, err := toFloat64(val)
+	if err == nil && math.Abs(f) > maximum {
+		err = ErrOverflow
+	}
+	if err != nil {
+		convErr := newConversionError(key, val, target, err)
+		if errors.Is(err, ErrOverflow) {
+			convErr.withRange(-maximum, maximum)
+		}
+		return 0, convErr
+	}
+	return f, nil
+}
+
+// convertBool converts the value to a boolean
+func convertBool(key string, val interface{}) (bool, error) {
+	b, err := toBool(val)
+	if err != nil {
+		return false, newConversionError(key, val, "bool", err)
+	}
+	return b, nil
+}
+
+// convertString converts the value to a string
+func convertString(key string, val interface{}, target string) (string, error) {
+	switch v := val.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		return "", newConversionError(key, val, target, ErrWrongType)
+	}
+}
+
+// GetIntE get param by key, return integer or a *ConversionError
+func (p *Params) GetIntE(key string) (int, error) {
+	i, err := p.getSigned(key, "int", math.MinInt, math.MaxInt)
+	return int(i), err
+}
+
+// GetInt8E get param by key, return integer or a *ConversionError
+func (p *Params) GetInt8E(key string) (int8, error) {
+	i, err := p.getSigned(key, "int8", math.MinInt8, math.MaxInt8)
+	return int8(i), err
+}
+
+// GetInt16E get param by key, return integer or a *ConversionError
+func (p *Params) GetInt16E(key string) (int16, error) {
+	i, err := p.getSigned(key, "int16", math.MinInt16, math.MaxInt16)
+	return int16(i), err
+}
+
+// GetInt32E get param by key, return integer or a *ConversionError
+func (p *Params) GetInt32E(key string) (int32, error) {
+	i, err := p.getSigned(key, "int32", math.MinInt32, math.MaxInt32)
+	return int32(i), err
+}
+
+// GetInt64E get param by key, return integer or a *ConversionError
+func (p *Params) GetInt64E(key string) (int64, error) {
+	return p.getSigned(key, "int64", math.MinInt64, math.MaxInt64)
+}
+
+// GetUintE get param by key, return unsigned integer or a *ConversionError
+func (p *Params) GetUintE(key string) (uint, error) {
+	u, err := p.getUnsigned(key, "uint", math.MaxUint)
+	return uint(u), err
+}
+
+// GetUint8E get param by key, return unsigned integer or a *ConversionError
+func (p *Params) GetUint8E(key string) (uint8, error) {
+	u, err := p.getUnsigned(key, "uint8", math.MaxUint8)
+	return uint8(u), err
+}
+
+// GetUint16E get param by key, return unsigned integer or a *ConversionError
+func (p *Params) GetUint16E(key string) (uint16, error) {
+	u, err := p.getUnsigned(key, "uint16", math.MaxUint16)
+	return uint16(u), err
+}
+
+// GetUint32E get param by key, return unsigned integer or a *ConversionError
+func (p *Params) GetUint32E(key string) (uint32, error) {
+	u, err := p.getUnsigned(key, "uint32", math.MaxUint32)
+	return uint32(u), err
+}
+
+// GetUint64E get param by key, return unsigned integer or a *ConversionError
+func (p *Params) GetUint64E(key string) (uint64, error) {
+	return p.getUnsigned(key, "uint64", math.MaxUint64)
+}
+
+// GetFloat32E get param by key, return float or a *ConversionError
+func (p *Params) GetFloat32E(key string) (float32, error) {
+	val, err := p.getValue(key, "float32")
+	if err != nil {
+		return 0, err
+	}
+	f, err := convertFloat(key, val, "float32", math.MaxFloat32)
+	return float32(f), err
+}
+
+// GetFloatE get param by key, return float or a *ConversionError
+func (p *Params) GetFloatE(key string) (float64, error) {
+	val, err := p.getValue(key, "float64")
+	if err != nil {
+		return 0, err
+	}
+	return convertFloat(key, val, "float64", math.MaxFloat64)
+}
+
+// GetBoolE get param by key, return boolean or a *ConversionError
+func (p *Params) GetBoolE(key string) (bool, error) {
+	val, err := p.getValue(key, "bool")
+	if err != nil {
+		return false, err
+	}
+	return convertBool(key, val)
+}
+
+// GetStringE get param by key, return string or a *ConversionError
+func (p *Params) GetStringE(key string) (string, error) {
+	val, err := p.getValue(key, "string")
+	if err != nil {
+		return "", err
+	}
+	return convertString(key, val, "string")
+}
+
+// GetBytesE get param by key, return slice of bytes (strings are base64 decoded) or a *ConversionError
+func (p *Params) GetBytesE(key string) ([]byte, error) {
+	val, err := p.getValue(key, "[]byte")
+	if err != nil {
+		return nil, err
+	}
+	switch v := val.(type) {
+	case []byte:
+		return v, nil
+	case string:
+		decoded, decodeErr := base64.StdEncoding.DecodeString(v)
+		if decodeErr != nil {
+			return nil, newConversionError(key, val, "[]byte", ErrParse)
+		}
+		return decoded, nil
+	default:
+		return nil, newConversionError(key, val, "[]byte", ErrWrongType)
+	}
+}
+
+// sliceValues returns the elements of a slice value, strings are split by commas
+func sliceValues(val interface{}) ([]interface{}, bool) {
+	switch v := val.(type) {
+	case []interface{}:
+		return v, true
+	case string:
+		if v == "" {
+			return []interface{}{}, true
+		}
+		raw := strings.Split(v, ",")
+		values := make([]interface{}, len(raw))
+		for i, s := range raw {
+			values[i] = strings.TrimSpace(s)
+		}
+		return values, true
+	case []byte:
+		return sliceValues(string(v))
+	}
+	rv := reflect.ValueOf(val)
+	if rv.Kind() != reflect.Slice {
+		return nil, false
+	}
+	values := make([]interface{}, rv.Len())
+	for i := range values {
+		values[i] = rv.Index(i).Interface()
+	}
+	return values, true
+}
+
+// getSlice converts every element of the slice value of the key
+func getSlice[T any](p *Params, key, target string, convert func(key string, val interface{}) (T, error)) ([]T, error) {
+	val, err := p.getValue(key, target)
+	if err != nil {
+		return nil, err
+	}
+	values, ok := sliceValues(val)
+	if !ok {
+		return nil, newConversionError(key, val, target, ErrWrongType)
+	}
+	slice := make([]T, 0, len(values))
+	for i, v := range values {
+		converted, convErr := convert(key+"."+strconv.Itoa(i), v)
+		if convErr != nil {
+			return nil, convErr
+		}
+		slice = append(slice, converted)
+	}
+	return slice, nil
+}
+
+// sliceOk returns the slice and true, or an empty slice and false if the conversion failed
+func sliceOk[T any](slice []T, err error) ([]T, bool) {
+	if err != nil {
+		return []T{}, false
+	}
+	return slice, true
+}
+
+// GetIntSliceE get param by key, return slice of integers or a *ConversionError
+func (p *Params) GetIntSliceE(key string) ([]int, error) {
+	return getSlice(p, key, "[]int", func(elemKey string, val interface{}) (int, error) {
+		i, err := convertSigned(elemKey, val, "int", math.MinInt, math.MaxInt)
+		return int(i), err
+	})
+}
+
+// GetInt32SliceE get param by key, return slice of integers or a *ConversionError
+func (p *Params) GetInt32SliceE(key string) ([]int32, error) {
+	return getSlice(p, key, "[]int32", func(elemKey string, val interface{}) (int32, error) {
+		i, err := convertSigned(elemKey, val, "int32", math.MinInt32, math.MaxInt32)
+		return int32(i), err
+	})
+}
+
+// GetInt64SliceE get param by key, return slice of integers or a *ConversionError
+func (p *Params) GetInt64SliceE(key string) ([]int64, error) {
+	return getSlice(p, key, "[]int64", func(elemKey string, val interface{}) (int64, error) {
+		return convertSigned(elemKey, val, "int64", math.MinInt64, math.MaxInt64)
+	})
+}
+
+// GetUint64SliceE get param by key, return slice of unsigned integers or a *ConversionError
+func (p *Params) GetUint64SliceE(key string) ([]uint64, error) {
+	return getSlice(p, key, "[]uint64", func(elemKey string, val interface{}) (uint64, error) {
+		return convertUnsigned(elemKey, val, "uint64", math.MaxUint64)
+	})
+}
+
+// GetFloatSliceE get param by key, return slice of floats or a *ConversionError
+func (p *Params) GetFloatSliceE(key string) ([]float64, error) {
+	return getSlice(p, key, "[]float64", func(elemKey string, val interface{}) (float64, error) {
+		return convertFloat(elemKey, val, "float64", math.MaxFloat64)
+	})
+}
+
+// GetBoolSliceE get param by key, return slice of booleans or a *ConversionError
+func (p *Params) GetBoolSliceE(key string) ([]bool, error) {
+	return getSlice(p, key, "[]bool", convertBool)
+}
+
+// GetStringSliceE get param by key, return slice of strings or a *ConversionError
+func (p *Params) GetStringSliceE(key string) ([]string, error) {
+	val, err := p.getValue(key, "[]string")
+	if err != nil {
+		return nil, err
+	}
+	if v, ok := val.([]string); ok {
+		return v, nil
+	}
+	return getSlice(p, key, "[]string", func(elemKey string, val interface{}) (string, error) {
+		return convertString(elemKey, val, "string")
+	})
+}
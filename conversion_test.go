@@ -0,0 +1,465 @@
+package parameters
+
+import (
+	"encoding/json"
+	"math"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newConversionParams returns the params used by the conversion tests
+func newConversionParams() *Params {
+	return &Params{Values: map[string]interface{}{
+		"page":     "2",
+		"big":      "99999999999",
+		"huge":     "99999999999999999999",
+		"negative": -1,
+		"ratio":    0.5,
+		"fraction": 1.5,
+		"unsafe":   float64(1 << 60),
+		"number":   json.Number("42"),
+		"nan":      "NaN",
+		"null":     nil,
+		"text":     "abc",
+		"active":   true,
+		"flag":     "1",
+		"object":   map[string]interface{}{"a": "b"},
+		"ids":      []interface{}{1.0, "2", 3},
+		"bad_ids":  []interface{}{1.0, "x"},
+		"csv":      "1, 2,3",
+		"tags":     []interface{}{"a", "b"},
+		"encoded":  "aGVsbG8=",
+		"created":  "2024-05-01T10:00:00Z",
+
+		"huge_float": 1e300,
+		"flags":      "true, 0,false",
+	}}
+}
+
+// TestParams_GetIntE tests the integer getters returning errors
+func TestParams_GetIntE(t *testing.T) {
+	params := newConversionParams()
+
+	tests := []struct {
+		name     string
+		key      string
+		get      func(key string) (interface{}, error)
+		expected interface{}
+		reason   error
+		message  string
+	}{
+		{"int from string", "page", wrapErrGetter(params.GetIntE), 2, nil, ""},
+		{"int from json number", "number", wrapErrGetter(params.GetIntE), 42, nil, ""},
+		{"int32 overflow", "big", wrapErrGetter(params.GetInt32E), nil, ErrOverflow, "big must be an integer between -2147483648 and 2147483647"},
+		{"int64 overflow", "huge", wrapErrGetter(params.GetInt64E), nil, ErrOverflow, "huge must be an integer between -9223372036854775808 and 9223372036854775807"},
+		{"int8 from negative", "negative", wrapErrGetter(params.GetInt8E), int8(-1), nil, ""},
+		{"int16 from float", "ratio", wrapErrGetter(params.GetInt16E), nil, ErrParse, "ratio must be an integer"},
+		{"int from unsafe float", "unsafe", wrapErrGetter(params.GetIntE), nil, ErrOverflow, ""},
+		{"int from text", "text", wrapErrGetter(params.GetIntE), nil, ErrParse, "text must be an integer"},
+		{"int from bool", "active", wrapErrGetter(params.GetIntE), nil, ErrWrongType, "active must be an integer"},
+		{"int missing", "missing", wrapErrGetter(params.GetIntE), nil, ErrParamNotFound, "missing is required"},
+		{"int null", "null", wrapErrGetter(params.GetIntE), nil, ErrNullValue, "null must not be null"},
+		{"uint64 from negative", "negative", wrapErrGetter(params.GetUint64E), nil, ErrOverflow, "negative must be a non-negative integer between 0 and 18446744073709551615"},
+		{"uint64 from string", "huge", wrapErrGetter(params.GetUint64E), nil, ErrOverflow, ""},
+		{"uint64 from float", "fraction", wrapErrGetter(params.GetUint64E), nil, ErrParse, ""},
+		{"uint from string", "page", wrapErrGetter(params.GetUintE), uint(2), nil, ""},
+		{"uint8 from json number", "number", wrapErrGetter(params.GetUint8E), uint8(42), nil, ""},
+		{"uint8 overflow", "big", wrapErrGetter(params.GetUint8E), nil, ErrOverflow, "big must be a non-negative integer between 0 and 255"},
+		{"uint16 from negative", "negative", wrapErrGetter(params.GetUint16E), nil, ErrOverflow, "negative must be a non-negative integer between 0 and 65535"},
+		{"uint32 overflow", "big", wrapErrGetter(params.GetUint32E), nil, ErrOverflow, "big must be a non-negative integer between 0 and 4294967295"},
+		{"uint32 from text", "text", wrapErrGetter(params.GetUint32E), nil, ErrParse, "text must be a non-negative integer"},
+		{"float32 from string", "page", wrapErrGetter(params.GetFloat32E), float32(2), nil, ""},
+		{"float32 from float", "fraction", wrapErrGetter(params.GetFloat32E), float32(1.5), nil, ""},
+		{"float32 overflow", "huge_float", wrapErrGetter(params.GetFloat32E), nil, ErrOverflow, ""},
+		{"float32 from nan", "nan", wrapErrGetter(params.GetFloat32E), nil, ErrParse, "nan must be a number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			val, err := tt.get(tt.key)
+			if tt.reason == nil {
+				require.NoError(t, err)
+				assert.EqualValues(t, tt.expected, val)
+				return
+			}
+
+			var convErr *ConversionError
+			require.ErrorAs(t, err, &convErr)
+			require.ErrorIs(t, err, tt.reason)
+			require.ErrorIs(t, err, ErrConversionFailed)
+			assert.Equal(t, tt.key, convErr.Key)
+			if tt.message != "" {
+				assert.Equal(t, tt.message, err.Error())
+			}
+		})
+	}
+}
+
+// wrapErrGetter wraps a typed getter for the table tests
+func wrapErrGetter[T any](getter func(key string) (T, error)) func(key string) (interface{}, error) {
+	return func(key string) (interface{}, error) {
+		return getter(key)
+	}
+}
+
+// TestParams_GetFloatE tests the float getter returning errors
+func TestParams_GetFloatE(t *testing.T) {
+	params := newConversionParams()
+
+	f, err := params.GetFloatE("ratio")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, f, 0.0001)
+
+	f, err = params.GetFloatE("negative")
+	require.NoError(t, err)
+	assert.InDelta(t, -1.0, f, 0.0001)
+
+	f, err = params.GetFloatE("page")
+	require.NoError(t, err)
+	assert.InDelta(t, 2.0, f, 0.0001)
+
+	_, err = params.GetFloatE("nan")
+	require.ErrorIs(t, err, ErrParse)
+
+	_, err = params.GetFloatE("missing")
+	require.ErrorIs(t, err, ErrParamNotFound)
+	assert.Equal(t, "missing is required", err.Error())
+
+	_, err = params.GetFloatE("object")
+	require.ErrorIs(t, err, ErrWrongType)
+	assert.Equal(t, "object must be a number", err.Error())
+}
+
+// TestParams_GetBoolE tests the boolean getter returning errors
+func TestParams_GetBoolE(t *testing.T) {
+	params := newConversionParams()
+
+	b, err := params.GetBoolE("active")
+	require.NoError(t, err)
+	assert.True(t, b)
+
+	b, err = params.GetBoolE("flag")
+	require.NoError(t, err)
+	assert.True(t, b)
+
+	_, err = params.GetBoolE("text")
+	require.ErrorIs(t, err, ErrParse)
+	assert.Equal(t, "text must be a boolean", err.Error())
+
+	_, err = params.GetBoolE("null")
+	require.ErrorIs(t, err, ErrNullValue)
+}
+
+// TestParams_GetStringE tests the string, bytes and time getters returning errors
+func TestParams_GetStringE(t *testing.T) {
+	params := newConversionParams()
+
+	s, err := params.GetStringE("text")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", s)
+
+	_, err = params.GetStringE("ratio")
+	require.ErrorIs(t, err, ErrWrongType)
+	assert.Equal(t, "ratio must be a string", err.Error())
+
+	b, err := params.GetBytesE("encoded")
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(b))
+
+	_, err = params.GetBytesE("nan")
+	require.ErrorIs(t, err, ErrParse)
+
+	created, err := params.GetTimeE("created")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC), created)
+
+	_, err = params.GetTimeE("text")
+	require.ErrorIs(t, err, ErrParse)
+	assert.Equal(t, "text must be a time", err.Error())
+
+	_, err = params.GetTimeE("object")
+	require.ErrorIs(t, err, ErrWrongType)
+}
+
+// TestParams_GetSliceE tests the slice getters returning errors
+func TestParams_GetSliceE(t *testing.T) {
+	params := newConversionParams()
+
+	ids, err := params.GetIntSliceE("ids")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+
+	ids, err = params.GetIntSliceE("csv")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+
+	_, err = params.GetIntSliceE("bad_ids")
+	var convErr *ConversionError
+	require.ErrorAs(t, err, &convErr)
+	assert.Equal(t, "bad_ids.1", convErr.Key)
+	assert.Equal(t, "x", convErr.Value)
+	assert.Equal(t, "bad_ids.1 must be an integer", err.Error())
+
+	_, err = params.GetIntSliceE("active")
+	require.ErrorIs(t, err, ErrWrongType)
+	assert.Equal(t, "active must be a list of integer values", err.Error())
+
+	uints, err := params.GetUint64SliceE("ids")
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2, 3}, uints)
+
+	floats, err := params.GetFloatSliceE("ids")
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1, 2, 3}, floats)
+
+	int32s, err := params.GetInt32SliceE("csv")
+	require.NoError(t, err)
+	assert.Equal(t, []int32{1, 2, 3}, int32s)
+
+	_, err = params.GetInt32SliceE("bad_ids")
+	require.ErrorIs(t, err, ErrParse)
+
+	int64s, err := params.GetInt64SliceE("ids")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 3}, int64s)
+
+	bools, err := params.GetBoolSliceE("flags")
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false, false}, bools)
+
+	_, err = params.GetBoolSliceE("tags")
+	require.ErrorIs(t, err, ErrParse)
+	assert.Equal(t, "tags.0 must be a boolean", err.Error())
+
+	tags, err := params.GetStringSliceE("tags")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, tags)
+
+	_, err = params.GetStringSliceE("ids")
+	require.ErrorIs(t, err, ErrWrongType)
+
+	_, err = params.GetIntSliceE("missing")
+	require.ErrorIs(t, err, ErrParamNotFound)
+}
+
+// TestParams_SliceOkEmpty tests that the slice Ok getters return an empty slice when the conversion fails
+func TestParams_SliceOkEmpty(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{"bad": []interface{}{"x", true}}}
+	colors := NewEnum("red", "green")
+
+	tests := []struct {
+		name  string
+		getOk func(key string) (interface{}, bool)
+		empty interface{}
+	}{
+		{"duration", func(key string) (interface{}, bool) { return params.GetDurationSliceOk(key) }, []time.Duration{}},
+		{"uuid", func(key string) (interface{}, bool) { return params.GetUUIDSliceOk(key) }, []UUID{}},
+		{"ip", func(key string) (interface{}, bool) { return params.GetIPSliceOk(key) }, []netip.Addr{}},
+		{"prefix", func(key string) (interface{}, bool) { return params.GetPrefixSliceOk(key) }, []netip.Prefix{}},
+		{"addr port", func(key string) (interface{}, bool) { return params.GetAddrPortSliceOk(key) }, []netip.AddrPort{}},
+		{"enum", func(key string) (interface{}, bool) { return params.GetEnumSliceOk(key, "a") }, []string{}},
+		{"enum type", func(key string) (interface{}, bool) { return colors.GetSliceOk(params, key) }, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"bad", "missing"} {
+				val, ok := tt.getOk(key)
+				assert.False(t, ok, key)
+				assert.Equal(t, tt.empty, val, key)
+			}
+		})
+	}
+}
+
+// TestToInt64 tests the integer conversion of the supported types
+func TestToInt64(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected int64
+		reason   error
+	}{
+		{int8(-5), -5, nil},
+		{uint32(7), 7, nil},
+		{uint64(math.MaxUint64), 0, ErrOverflow},
+		{float32(3), 3, nil},
+		{math.Inf(1), 0, ErrParse},
+		{[]byte("12"), 12, nil},
+		{json.Number("1.5"), 0, ErrParse},
+		{struct{}{}, 0, ErrWrongType},
+	}
+	for _, tt := range tests {
+		i, err := toInt64(tt.value)
+		if tt.reason != nil {
+			require.ErrorIs(t, err, tt.reason, "%v", tt.value)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, i)
+	}
+}
+
+// TestParams_NumericGetters tests the unsigned, float32 and slice getters
+func TestParams_NumericGetters(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"port":   8080.0,
+		"level":  "7",
+		"big":    70000,
+		"ratio":  "0.25",
+		"ids":    "1, 2, 3",
+		"counts": []interface{}{1.0, "-2", int64(3)},
+		"flags":  []interface{}{true, "false", 1},
+		"bad":    []interface{}{1.5},
+	}}
+
+	assert.Equal(t, uint(8080), params.GetUint("port"))
+	assert.Equal(t, uint8(7), params.GetUint8("level"))
+	assert.Equal(t, uint16(8080), params.GetUint16("port"))
+	assert.Equal(t, uint32(70000), params.GetUint32("big"))
+	assert.InDelta(t, float32(0.25), params.GetFloat32("ratio"), 0)
+
+	_, ok := params.GetUint16Ok("big")
+	assert.False(t, ok)
+	_, ok = params.GetUint8Ok("missing")
+	assert.False(t, ok)
+
+	assert.Equal(t, []int64{1, 2, 3}, params.GetInt64Slice("ids"))
+	assert.Equal(t, []int32{1, -2, 3}, params.GetInt32Slice("counts"))
+	assert.Equal(t, []bool{true, false, true}, params.GetBoolSlice("flags"))
+
+	_, ok = params.GetInt32SliceOk("bad")
+	assert.False(t, ok)
+	_, ok = params.GetBoolSliceOk("bad")
+	assert.False(t, ok)
+}
+
+// TestImbue_Numeric tests that Imbue fills the unsigned, float32 and slice fields
+func TestImbue_Numeric(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"retries":  "3",
+		"port":     443,
+		"priority": 2.0,
+		"weight":   "0.5",
+		"ids":      "10,20",
+		"offsets":  []interface{}{-1, 1},
+		"enabled":  "true,false",
+		"overflow": 300,
+	}}
+
+	type testType struct {
+		Retries  uint
+		Port     uint16
+		Priority uint32
+		Weight   float32
+		Ids      []int64
+		Offsets  []int32
+		Enabled  []bool
+		Overflow uint8
+	}
+
+	var obj testType
+	params.Imbue(&obj)
+
+	assert.Equal(t, uint(3), obj.Retries)
+	assert.Equal(t, uint16(443), obj.Port)
+	assert.Equal(t, uint32(2), obj.Priority)
+	assert.InDelta(t, float32(0.5), obj.Weight, 0)
+	assert.Equal(t, []int64{10, 20}, obj.Ids)
+	assert.Equal(t, []int32{-1, 1}, obj.Offsets)
+	assert.Equal(t, []bool{true, false}, obj.Enabled)
+	assert.Equal(t, uint8(0), obj.Overflow)
+}
+
+// TestParams_NumericConsistency tests that every numeric kind, string, []byte and json.Number convert
+// with the same rules in the scalar and slice getters
+func TestParams_NumericConsistency(t *testing.T) {
+	equivalent := []interface{}{
+		3, int8(3), int64(3), uint8(3), uint64(3), float32(3), 3.0,
+		"3", " 3 ", "3.0", []byte("3"), json.Number("3"), json.Number("3.0"), json.Number("3e0"),
+	}
+	for _, val := range equivalent {
+		params := &Params{Values: map[string]interface{}{"n": val, "list": []interface{}{val}}}
+
+		i, ok := params.GetIntOk("n")
+		assert.True(t, ok, "%#v", val)
+		assert.Equal(t, 3, i)
+
+		u, ok := params.GetUint8Ok("n")
+		assert.True(t, ok, "%#v", val)
+		assert.Equal(t, uint8(3), u)
+
+		f, ok := params.GetFloatOk("n")
+		assert.True(t, ok, "%#v", val)
+		assert.InDelta(t, 3.0, f, 0)
+
+		b, ok := params.GetBoolOk("n")
+		assert.True(t, ok, "%#v", val)
+		assert.True(t, b)
+
+		ints, ok := params.GetIntSliceOk("list")
+		assert.True(t, ok, "%#v", val)
+		assert.Equal(t, []int{3}, ints)
+
+		floats, ok := params.GetFloatSliceOk("list")
+		assert.True(t, ok, "%#v", val)
+		assert.Equal(t, []float64{3}, floats)
+	}
+
+	rejected := []struct {
+		value  interface{}
+		reason error
+	}{
+		{2.5, ErrParse},
+		{"2.5", ErrParse},
+		{json.Number("2.5"), ErrParse},
+		{math.NaN(), ErrParse},
+		{"NaN", ErrParse},
+		{math.Inf(-1), ErrParse},
+		{"+Inf", ErrParse},
+		{"1e400", ErrParse},
+		{"3e0", ErrParse},
+		{"1_000", ErrParse},
+		{"0x1p4", ErrParse},
+		{json.Number("1e400"), ErrOverflow},
+		{"99999999999999999999", ErrOverflow},
+		{float64(1 << 60), ErrOverflow},
+	}
+	for _, tt := range rejected {
+		params := &Params{Values: map[string]interface{}{"n": tt.value, "list": []interface{}{tt.value}}}
+
+		_, err := params.GetIntE("n")
+		require.ErrorIs(t, err, tt.reason, "%#v", tt.value)
+
+		_, err = params.GetIntSliceE("list")
+		require.ErrorIs(t, err, tt.reason, "%#v", tt.value)
+
+		_, ok := params.GetIntOk("n")
+		assert.False(t, ok, "%#v", tt.value)
+	}
+
+	// Floats accept every finite number, NaN and Infinity are rejected
+	params := &Params{Values: map[string]interface{}{
+		"int":   int64(7),
+		"uint":  uint32(7),
+		"nan":   "NaN",
+		"inf":   math.Inf(1),
+		"large": "1e400",
+		"list":  "1.5, 2 ,NaN",
+	}}
+	assert.InDelta(t, 7.0, params.GetFloat("int"), 0)
+	assert.InDelta(t, 7.0, params.GetFloat("uint"), 0)
+	_, err := params.GetFloatE("nan")
+	require.ErrorIs(t, err, ErrParse)
+	_, err = params.GetFloatE("inf")
+	require.ErrorIs(t, err, ErrParse)
+	_, err = params.GetFloatE("large")
+	require.ErrorIs(t, err, ErrOverflow)
+	_, err = params.GetFloatSliceE("list")
+	require.ErrorIs(t, err, ErrParse)
+
+	// Comma separated strings are trimmed by every slice getter
+	params = &Params{Values: map[string]interface{}{"csv": " a , b,c "}}
+	assert.Equal(t, []string{"a", "b", "c"}, params.GetStringSlice("csv"))
+}
@@ -0,0 +1,195 @@
+package parameters
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Errors returned by the generic getters
+var (
+	// ErrParamNotFound is returned when the key is not found in the params
+	ErrParamNotFound = errors.New("parameters: key not found")
+
+	// ErrNoConverter is returned when no converter is registered for the target type
+	ErrNoConverter = errors.New("parameters: no converter registered")
+
+	// ErrConversionFailed is returned when the value cannot be converted to the target type
+	ErrConversionFailed = errors.New("parameters: value cannot be converted")
+)
+
+// converterFunc converts the value of the key to the target type
+type converterFunc func(p *Params, key string) (interface{}, error)
+
+// The conversion registry by target type
+var (
+	convertersMu sync.RWMutex
+	converters   = builtinConverters()
+)
+
+// builtinConverters returns the converters for the types of the existing getters
+func builtinConverters() map[reflect.Type]converterFunc {
+	c := make(map[reflect.Type]converterFunc)
+	addErrGetter(c, (*Params).GetBigFloatE)
+	addErrGetter(c, (*Params).GetBigIntE)
+	addErrGetter(c, (*Params).GetBigRatE)
+	addErrGetter(c, (*Params).GetBoolE)
+	addErrGetter(c, (*Params).GetBoolSliceE)
+	addErrGetter(c, (*Params).GetBytesE)
+	addGetter(c, (*Params).GetFileOk)
+	addGetter(c, (*Params).GetFilesOk)
+	addErrGetter(c, (*Params).GetDateE)
+	addErrGetter(c, (*Params).GetDecimalE)
+	addErrGetter(c, (*Params).GetDurationE)
+	addErrGetter(c, (*Params).GetDurationSliceE)
+	addErrGetter(c, (*Params).GetFloat32E)
+	addErrGetter(c, (*Params).GetFloatE)
+	addErrGetter(c, (*Params).GetFloatSliceE)
+	addErrGetter(c, (*Params).GetInt16E)
+	addErrGetter(c, (*Params).GetInt32E)
+	addErrGetter(c, (*Params).GetInt32SliceE)
+	addErrGetter(c, (*Params).GetInt64E)
+	addErrGetter(c, (*Params).GetInt64SliceE)
+	addErrGetter(c, (*Params).GetInt8E)
+	addErrGetter(c, (*Params).GetIntE)
+	addErrGetter(c, (*Params).GetIntSliceE)
+	addErrGetter(c, (*Params).GetISOWeekE)
+	addGetter(c, (*Params).GetJSONOk)
+	addErrGetter(c, (*Params).GetLocationE)
+	addErrGetter(c, (*Params).GetMonthE)
+	addErrGetter(c, (*Params).GetStringE)
+	addErrGetter(c, (*Params).GetStringSliceE)
+	addErrGetter(c, (*Params).GetTimeE)
+	addErrGetter(c, (*Params).GetTimeOfDayE)
+	addErrGetter(c, (*Params).GetUint16E)
+	addErrGetter(c, (*Params).GetUint32E)
+	addErrGetter(c, (*Params).GetUint64E)
+	addErrGetter(c, (*Params).GetUint8E)
+	addErrGetter(c, (*Params).GetUintE)
+	addErrGetter(c, (*Params).GetUint64SliceE)
+	addErrGetter(c, func(p *Params, key string) (netip.Addr, error) { return p.GetIPE(key) })
+	addErrGetter(c, func(p *Params, key string) ([]netip.Addr, error) { return p.GetIPSliceE(key) })
+	addErrGetter(c, func(p *Params, key string) (netip.Prefix, error) { return p.GetPrefixE(key) })
+	addErrGetter(c, func(p *Params, key string) ([]netip.Prefix, error) { return p.GetPrefixSliceE(key) })
+	addErrGetter(c, func(p *Params, key string) (netip.AddrPort, error) { return p.GetAddrPortE(key) })
+	addErrGetter(c, func(p *Params, key string) ([]netip.AddrPort, error) { return p.GetAddrPortSliceE(key) })
+	addErrGetter(c, func(p *Params, key string) (*url.URL, error) { return p.GetURLE(key, URLOptions{}) })
+	addErrGetter(c, func(p *Params, key string) (UUID, error) { return p.GetUUIDE(key) })
+	addErrGetter(c, func(p *Params, key string) ([]UUID, error) { return p.GetUUIDSliceE(key) })
+	c[reflect.TypeFor[*time.Time]()] = func(p *Params, key string) (interface{}, error) {
+		t, err := p.GetTimeE(key)
+		if err != nil {
+			return nil, err
+		}
+		return &t, nil
+	}
+	c[reflect.TypeFor[*FileInfo]()] = func(p *Params, key string) (interface{}, error) {
+		return p.GetFileInfo(key)
+	}
+	return c
+}
+
+// addGetter adds a converter backed by a getter
+func addGetter[T any](c map[reflect.Type]converterFunc, getter func(p *Params, key string) (T, bool)) {
+	target := reflect.TypeFor[T]()
+	c[target] = func(p *Params, key string) (interface{}, error) {
+		val, ok := getter(p, key)
+		if !ok {
+			return nil, conversionFailed(key, target)
+		}
+		return val, nil
+	}
+}
+
+// addErrGetter adds a converter backed by an error returning getter
+func addErrGetter[T any](c map[reflect.Type]converterFunc, getter func(p *Params, key string) (T, error)) {
+	c[reflect.TypeFor[T]()] = func(p *Params, key string) (interface{}, error) {
+		return getter(p, key)
+	}
+}
+
+// conversionFailed returns the error for a value that cannot be converted
+func conversionFailed(key string, target reflect.Type) error {
+	return fmt.Errorf("%w: %q to %s", ErrConversionFailed, key, target)
+}
+
+// lookupConverter returns the converter for the target type
+func lookupConverter(target reflect.Type) (converterFunc, bool) {
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+	convert, ok := converters[target]
+	return convert, ok
+}
+
+// RegisterConverter registers the conversion of a raw parameter value to the type T.
+// The converter is used by Get, GetOr and Imbue, replacing any existing converter for T.
+func RegisterConverter[T any](fn func(value interface{}) (T, error)) {
+	target := reflect.TypeFor[T]()
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+	converters[target] = func(p *Params, key string) (interface{}, error) {
+		val, _ := p.Get(key)
+		converted, err := fn(val)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", conversionFailed(key, target), err)
+		}
+		return converted, nil
+	}
+}
+
+// Get get param by key, return the value converted to the type T
+//
+//	page, err := parameters.Get[int](params, "page")
+func Get[T any](p *Params, key string) (T, error) {
+	var zero T
+	val, ok := p.Get(key)
+	if !ok {
+		return zero, fmt.Errorf("%w: %q", ErrParamNotFound, key)
+	}
+
+	target := reflect.TypeFor[T]()
+	convert, found := lookupConverter(target)
+	if !found {
+		// The value is already of the target type
+		if typed, isTyped := val.(T); isTyped {
+			return typed, nil
+		}
+		return zero, fmt.Errorf("%w: %s", ErrNoConverter, target)
+	}
+
+	converted, err := convert(p, key)
+	if err != nil {
+		return zero, err
+	}
+	typed, isTyped := converted.(T)
+	if !isTyped {
+		return zero, conversionFailed(key, target)
+	}
+	return typed, nil
+}
+
+// GetOr get param by key, return the value converted to the type T or the default value
+//
+//	limit := parameters.GetOr(params, "limit", 25)
+func GetOr[T any](p *Params, key string, def T) T {
+	if val, err := Get[T](p, key); err == nil {
+		return val
+	}
+	return def
+}
+
+// imbueConverted sets the field using the registered converter for its type
+func imbueConverted(p *Params, key string, field *reflect.Value) bool {
+	convert, found := lookupConverter(field.Type())
+	if !found {
+		return false
+	}
+	if converted, err := convert(p, key); err == nil && converted != nil {
+		field.Set(reflect.ValueOf(converted))
+	}
+	return true
+}
@@ -0,0 +1,192 @@
+package parameters
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testColor is a custom type used to test the converter registry
+type testColor struct {
+	R, G, B uint8
+}
+
+// errInvalidColor is returned when the color cannot be parsed
+var errInvalidColor = errors.New("invalid color")
+
+// parseTestColor converts "r,g,b" into a color
+func parseTestColor(value interface{}) (testColor, error) {
+	s, ok := value.(string)
+	if !ok {
+		return testColor{}, errInvalidColor
+	}
+	var c testColor
+	parts := strings.Split(s, ",")
+	if len(parts) != 3 {
+		return testColor{}, errInvalidColor
+	}
+	for i, ptr := range []*uint8{&c.R, &c.G, &c.B} {
+		v, err := strconv.ParseUint(parts[i], 10, 8)
+		if err != nil {
+			return testColor{}, errInvalidColor
+		}
+		*ptr = uint8(v)
+	}
+	return c, nil
+}
+
+// registerTestColor registers the test color converter and removes it after the test
+func registerTestColor(t *testing.T) {
+	t.Helper()
+	RegisterConverter(parseTestColor)
+	t.Cleanup(func() {
+		convertersMu.Lock()
+		delete(converters, reflect.TypeFor[testColor]())
+		convertersMu.Unlock()
+	})
+}
+
+// TestGet tests the generic Get function
+func TestGet(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"page":    "2",
+		"ratio":   0.5,
+		"active":  true,
+		"tags":    "a,b",
+		"ids":     []interface{}{1.0, 2.0},
+		"big":     float64(1 << 40),
+		"small":   "200",
+		"created": "2024-05-01T10:00:00Z",
+		"meta":    map[string]interface{}{"a": "b"},
+		"raw":     time.Duration(5),
+	}}
+
+	page, err := Get[int](params, "page")
+	require.NoError(t, err)
+	assert.Equal(t, 2, page)
+
+	ratio, err := Get[float64](params, "ratio")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, ratio, 0.0001)
+
+	active, err := Get[bool](params, "active")
+	require.NoError(t, err)
+	assert.True(t, active)
+
+	tags, err := Get[[]string](params, "tags")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, tags)
+
+	ids, err := Get[[]int](params, "ids")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ids)
+
+	big, err := Get[int64](params, "big")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1<<40), big)
+
+	created, err := Get[time.Time](params, "created")
+	require.NoError(t, err)
+	assert.Equal(t, 2024, created.Year())
+
+	createdPtr, err := Get[*time.Time](params, "created")
+	require.NoError(t, err)
+	assert.True(t, created.Equal(*createdPtr))
+
+	meta, err := Get[map[string]interface{}](params, "meta")
+	require.NoError(t, err)
+	assert.Equal(t, "b", meta["a"])
+
+	raw, err := Get[interface{}](params, "page")
+	require.NoError(t, err)
+	assert.Equal(t, "2", raw)
+
+	t.Run("value already of the target type", func(t *testing.T) {
+		d, getErr := Get[time.Duration](params, "raw")
+		require.NoError(t, getErr)
+		assert.Equal(t, time.Duration(5), d)
+	})
+
+	t.Run("key not found", func(t *testing.T) {
+		_, getErr := Get[int](params, "missing")
+		require.ErrorIs(t, getErr, ErrParamNotFound)
+	})
+
+	t.Run("conversion failed", func(t *testing.T) {
+		_, getErr := Get[int8](params, "small")
+		require.ErrorIs(t, getErr, ErrConversionFailed)
+		require.ErrorIs(t, getErr, ErrOverflow)
+		assert.Equal(t, "small must be an integer between -128 and 127", getErr.Error())
+	})
+
+	t.Run("no converter", func(t *testing.T) {
+		_, getErr := Get[testColor](params, "page")
+		require.ErrorIs(t, getErr, ErrNoConverter)
+	})
+}
+
+// TestGetOr tests the generic GetOr function
+func TestGetOr(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"limit":  "50",
+		"offset": "abc",
+	}}
+
+	assert.Equal(t, 50, GetOr(params, "limit", 25))
+	assert.Equal(t, 10, GetOr(params, "offset", 10))
+	assert.Equal(t, 25, GetOr(params, "missing", 25))
+	assert.Equal(t, "desc", GetOr(params, "sort", "desc"))
+}
+
+// TestRegisterConverter tests registering a custom converter
+func TestRegisterConverter(t *testing.T) {
+	registerTestColor(t)
+
+	params := &Params{Values: map[string]interface{}{
+		"background": "10,20,30",
+		"foreground": "red",
+	}}
+
+	color, err := Get[testColor](params, "background")
+	require.NoError(t, err)
+	assert.Equal(t, testColor{R: 10, G: 20, B: 30}, color)
+
+	_, err = Get[testColor](params, "foreground")
+	require.ErrorIs(t, err, ErrConversionFailed)
+	require.ErrorIs(t, err, errInvalidColor)
+
+	assert.Equal(t, testColor{R: 1}, GetOr(params, "foreground", testColor{R: 1}))
+}
+
+// TestImbue_RegisteredConverters tests that Imbue uses the registered converters
+func TestImbue_RegisteredConverters(t *testing.T) {
+	registerTestColor(t)
+
+	params := &Params{Values: map[string]interface{}{
+		"background": "10,20,30",
+		"user_id":    "9007199254740993",
+		"level":      "12",
+		"meta":       map[string]interface{}{"a": "b"},
+	}}
+
+	type testType struct {
+		Background testColor
+		UserID     int64
+		Level      int8
+		Meta       map[string]interface{}
+	}
+
+	var obj testType
+	params.Imbue(&obj)
+
+	assert.Equal(t, testColor{R: 10, G: 20, B: 30}, obj.Background)
+	assert.Equal(t, int64(9007199254740993), obj.UserID)
+	assert.Equal(t, int8(12), obj.Level)
+	assert.Equal(t, map[string]interface{}{"a": "b"}, obj.Meta)
+}
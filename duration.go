@@ -0,0 +1,214 @@
+package parameters
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultDurationUnit is the unit of durations given as numbers (e.g. 5400 is 5400 seconds), unless
+// another unit is set with SetDurationUnit
+const DefaultDurationUnit = time.Second
+
+// isoDurationPattern matches ISO 8601 durations with weeks, days, hours, minutes and seconds (e.g. "PT1H30M")
+var isoDurationPattern = regexp.MustCompile(
+	`^([-+])?P(?:(\d+(?:[.,]\d+)?)W)?(?:(\d+(?:[.,]\d+)?)D)?(?:T(?:(\d+(?:[.,]\d+)?)H)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`,
+)
+
+// goDurationPattern matches the syntax of Go durations (e.g. "1h30m")
+var goDurationPattern = regexp.MustCompile(`^[-+]?(?:(?:\d+\.?\d*|\.\d+)(?:ns|us|µs|μs|ms|s|m|h))+$`)
+
+// isoDurationUnits are the units of the groups of isoDurationPattern
+var isoDurationUnits = []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+
+// toDuration converts the value to a duration, numbers are in the unit (nanoseconds if not positive)
+func toDuration(val interface{}, unit time.Duration) (time.Duration, error) {
+	if unit <= 0 {
+		unit = time.Nanosecond
+	}
+	switch v := val.(type) {
+	case time.Duration:
+		return v, nil
+	case string:
+		return parseDuration(strings.TrimSpace(v), unit)
+	case []byte:
+		return parseDuration(strings.TrimSpace(string(v)), unit)
+	}
+
+	// Integers are multiplied exactly, other numbers as floats
+	if i, err := toInt64(val); err == nil {
+		if i != 0 && (i > math.MaxInt64/int64(unit) || i < math.MinInt64/int64(unit)) {
+			return 0, ErrOverflow
+		}
+		return time.Duration(i) * unit, nil
+	}
+	f, err := toFloat64(val)
+	if err != nil {
+		return 0, err
+	}
+	return scaleDuration(f, unit)
+}
+
+// parseDuration parses a Go duration, an ISO 8601 duration or a number in the unit
+func parseDuration(s string, unit time.Duration) (time.Duration, error) {
+	if s == "" {
+		return 0, ErrParse
+	}
+	if plainNumberPattern.MatchString(s) {
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return toDuration(i, unit)
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, parseReason(err)
+		}
+		return scaleDuration(f, unit)
+	}
+	if matches := isoDurationPattern.FindStringSubmatch(strings.ToUpper(s)); matches != nil {
+		return parseISODuration(matches)
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		// time.ParseDuration reports overflow as an invalid duration
+		if goDurationPattern.MatchString(s) {
+			return 0, ErrOverflow
+		}
+		return 0, ErrParse
+	}
+	return d, nil
+}
+
+// parseISODuration sums the groups matched by isoDurationPattern
+func parseISODuration(matches []string) (time.Duration, error) {
+	var total float64
+	found := false
+	for i, unit := range isoDurationUnits {
+		group := matches[i+2]
+		if group == "" {
+			continue
+		}
+		found = true
+		f, err := strconv.ParseFloat(strings.Replace(group, ",", ".", 1), 64)
+		if err != nil {
+			return 0, ErrParse
+		}
+		total += f * float64(unit)
+	}
+	if !found {
+		return 0, ErrParse
+	}
+	if matches[1] == "-" {
+		total = -total
+	}
+	return scaleDuration(total, time.Nanosecond)
+}
+
+// scaleDuration multiplies the number by the unit, checking for overflow
+func scaleDuration(f float64, unit time.Duration) (time.Duration, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, ErrParse
+	}
+	scaled := math.Round(f * float64(unit))
+	if scaled >= math.MaxInt64 || scaled < math.MinInt64 {
+		return 0, ErrOverflow
+	}
+	return time.Duration(scaled), nil
+}
+
+// SetDurationUnit sets the unit of durations given as numbers (DefaultDurationUnit if not set)
+func (p *Params) SetDurationUnit(unit time.Duration) {
+	p.durationUnit = unit
+}
+
+// DurationUnit returns the unit of durations given as numbers
+func (p *Params) DurationUnit() time.Duration {
+	if p.durationUnit <= 0 {
+		return DefaultDurationUnit
+	}
+	return p.durationUnit
+}
+
+// SetAllowNegativeDurations sets whether negative durations (e.g. "-5m") are accepted, they are rejected by default
+func (p *Params) SetAllowNegativeDurations(allow bool) {
+	p.negativeDurations = allow
+}
+
+// convertDuration converts the value to a duration, rejecting negative durations unless allowed
+func convertDuration(key string, val interface{}, unit time.Duration, allowNegative bool) (time.Duration, error) {
+	d, err := toDuration(val, unit)
+	if err == nil && d < 0 && !allowNegative {
+		err = ErrOverflow
+	}
+	if err != nil {
+		convErr := newConversionError(key, val, "time.Duration", err)
+		if errors.Is(err, ErrOverflow) {
+			minimum := time.Duration(0)
+			if allowNegative {
+				minimum = time.Duration(math.MinInt64)
+			}
+			convErr.withRange(minimum, time.Duration(math.MaxInt64))
+		}
+		return 0, convErr
+	}
+	return d, nil
+}
+
+// GetDurationInUnitE get param by key, return duration (numbers are in the unit) or a *ConversionError
+func (p *Params) GetDurationInUnitE(key string, unit time.Duration) (time.Duration, error) {
+	val, err := p.getValue(key, "time.Duration")
+	if err != nil {
+		return 0, err
+	}
+	return convertDuration(key, val, unit, p.negativeDurations)
+}
+
+// GetDurationE get param by key, return duration or a *ConversionError
+//
+// Accepts Go durations ("1h30m"), ISO 8601 durations ("PT90M") and numbers in the DurationUnit (5400)
+func (p *Params) GetDurationE(key string) (time.Duration, error) {
+	return p.GetDurationInUnitE(key, p.DurationUnit())
+}
+
+// GetDurationInUnitOk get param by key, return duration (numbers are in the unit)
+func (p *Params) GetDurationInUnitOk(key string, unit time.Duration) (time.Duration, bool) {
+	d, err := p.GetDurationInUnitE(key, unit)
+	return d, err == nil
+}
+
+// GetDurationInUnit get param by key, return duration (numbers are in the unit)
+func (p *Params) GetDurationInUnit(key string, unit time.Duration) time.Duration {
+	val, _ := p.GetDurationInUnitOk(key, unit)
+	return val
+}
+
+// GetDurationOk get param by key, return duration
+func (p *Params) GetDurationOk(key string) (time.Duration, bool) {
+	return p.GetDurationInUnitOk(key, p.DurationUnit())
+}
+
+// GetDuration get param by key, return duration
+func (p *Params) GetDuration(key string) time.Duration {
+	val, _ := p.GetDurationOk(key)
+	return val
+}
+
+// GetDurationSliceE get param by key, return slice of durations or a *ConversionError
+func (p *Params) GetDurationSliceE(key string) ([]time.Duration, error) {
+	return getSlice(p, key, "[]time.Duration", func(elemKey string, val interface{}) (time.Duration, error) {
+		return convertDuration(elemKey, val, p.DurationUnit(), p.negativeDurations)
+	})
+}
+
+// GetDurationSliceOk get param by key, return slice of durations
+func (p *Params) GetDurationSliceOk(key string) ([]time.Duration, bool) {
+	return sliceOk(p.GetDurationSliceE(key))
+}
+
+// GetDurationSlice get param by key, return slice of durations
+func (p *Params) GetDurationSlice(key string) []time.Duration {
+	val, _ := p.GetDurationSliceOk(key)
+	return val
+}
@@ -0,0 +1,135 @@
+package parameters
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParams_GetDurationE tests parsing the supported duration forms
+func TestParams_GetDurationE(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected time.Duration
+		reason   error
+	}{
+		{"go duration", "1h30m", 90 * time.Minute, nil},
+		{"go duration with fraction", "1.5s", 1500 * time.Millisecond, nil},
+		{"iso 8601", "PT90M", 90 * time.Minute, nil},
+		{"iso 8601 with days", "P1DT2H", 26 * time.Hour, nil},
+		{"iso 8601 with weeks", "P2W", 14 * 24 * time.Hour, nil},
+		{"iso 8601 with fraction", "PT0,5S", 500 * time.Millisecond, nil},
+		{"iso 8601 lower case", "pt1m", time.Minute, nil},
+		{"iso 8601 years", "P1Y", 0, ErrParse},
+		{"iso 8601 empty", "PT", 0, ErrParse},
+		{"integer seconds", 5400, 90 * time.Minute, nil},
+		{"float seconds", 1.5, 1500 * time.Millisecond, nil},
+		{"numeric string", "5400", 90 * time.Minute, nil},
+		{"json number", json.Number("60"), time.Minute, nil},
+		{"duration", 5 * time.Second, 5 * time.Second, nil},
+		{"zero", "0", 0, nil},
+		{"negative", "-5m", 0, ErrOverflow},
+		{"negative number", -5, 0, ErrOverflow},
+		{"overflow", "9999999999h", 0, ErrOverflow},
+		{"number overflow", int64(1) << 40, 0, ErrOverflow},
+		{"invalid", "soon", 0, ErrParse},
+		{"hex float", "0x1p4", 0, ErrParse},
+		{"underscores", "1_000", 0, ErrParse},
+		{"wrong type", true, 0, ErrWrongType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &Params{Values: map[string]interface{}{"timeout": tt.value}}
+			d, err := params.GetDurationE("timeout")
+			if tt.reason != nil {
+				require.ErrorIs(t, err, tt.reason)
+				_, ok := params.GetDurationOk("timeout")
+				assert.False(t, ok)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, d)
+			assert.Equal(t, tt.expected, params.GetDuration("timeout"))
+		})
+	}
+
+	t.Run("missing", func(t *testing.T) {
+		params := &Params{Values: map[string]interface{}{}}
+		_, err := params.GetDurationE("timeout")
+		require.ErrorIs(t, err, ErrParamNotFound)
+		assert.Equal(t, "timeout is required", err.Error())
+	})
+}
+
+// TestParams_GetDurationInUnit tests durations given as numbers in a unit
+func TestParams_GetDurationInUnit(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{"ttl": 1500, "interval": "2m"}}
+
+	assert.Equal(t, 1500*time.Millisecond, params.GetDurationInUnit("ttl", time.Millisecond))
+	assert.Equal(t, 2*time.Minute, params.GetDurationInUnit("interval", time.Millisecond))
+
+	d, ok := params.GetDurationInUnitOk("ttl", time.Minute)
+	require.True(t, ok)
+	assert.Equal(t, 25*time.Hour, d)
+
+	// The unit of the params is used by GetDuration and kept by nested params
+	assert.Equal(t, DefaultDurationUnit, params.DurationUnit())
+	params.SetDurationUnit(time.Millisecond)
+	assert.Equal(t, 1500*time.Millisecond, params.GetDuration("ttl"))
+	assert.Equal(t, time.Millisecond, params.Clone().DurationUnit())
+}
+
+// TestParams_GetDurationNegative tests accepting negative durations
+func TestParams_GetDurationNegative(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{"offset": "-PT5M", "skew": "-1s"}}
+	_, ok := params.GetDurationOk("offset")
+	assert.False(t, ok)
+
+	params.SetAllowNegativeDurations(true)
+	assert.Equal(t, -5*time.Minute, params.GetDuration("offset"))
+	assert.Equal(t, -time.Second, params.GetDuration("skew"))
+}
+
+// TestParams_GetDurationSlice tests the duration slice getters
+func TestParams_GetDurationSlice(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"intervals": []interface{}{"1m", "PT2M", 180},
+		"csv":       "1m, 2m",
+		"bad":       []interface{}{"1m", "later"},
+	}}
+
+	assert.Equal(t, []time.Duration{time.Minute, 2 * time.Minute, 3 * time.Minute}, params.GetDurationSlice("intervals"))
+	assert.Equal(t, []time.Duration{time.Minute, 2 * time.Minute}, params.GetDurationSlice("csv"))
+
+	_, ok := params.GetDurationSliceOk("bad")
+	assert.False(t, ok)
+
+	_, err := params.GetDurationSliceE("bad")
+	assert.Equal(t, "bad.1 must be a duration", err.Error())
+}
+
+// TestImbue_Duration tests that Imbue sets duration fields
+func TestImbue_Duration(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"timeout":   "PT30S",
+		"max_age":   3600,
+		"intervals": "1m,5m",
+	}}
+
+	type testType struct {
+		Timeout   time.Duration
+		MaxAge    time.Duration
+		Intervals []time.Duration
+	}
+
+	var obj testType
+	params.Imbue(&obj)
+
+	assert.Equal(t, 30*time.Second, obj.Timeout)
+	assert.Equal(t, time.Hour, obj.MaxAge)
+	assert.Equal(t, []time.Duration{time.Minute, 5 * time.Minute}, obj.Intervals)
+}
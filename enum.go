@@ -0,0 +1,139 @@
+package parameters
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrEnumValue is the reason when the value is not one of the permitted values
+var ErrEnumValue = errors.New("parameters: value not permitted")
+
+// Enum is a set of permitted string values, matched ignoring case and surrounding whitespace
+//
+//	var sortOrder = parameters.NewEnum[SortOrder]("asc", "desc").Alias("ascending", "asc").Alias("descending", "desc")
+//
+//	order, err := sortOrder.Get(params, "order")
+type Enum[T ~string] struct {
+	values  []T
+	aliases map[string]T
+}
+
+// NewEnum creates an enum of the permitted values
+func NewEnum[T ~string](values ...T) *Enum[T] {
+	e := &Enum[T]{values: values, aliases: make(map[string]T, len(values))}
+	for _, v := range values {
+		e.aliases[normalizeEnum(string(v))] = v
+	}
+	return e
+}
+
+// Alias maps an alternative spelling to a permitted value, returns the enum for chaining
+func (e *Enum[T]) Alias(alias string, value T) *Enum[T] {
+	e.aliases[normalizeEnum(alias)] = value
+	return e
+}
+
+// Values returns the permitted values
+func (e *Enum[T]) Values() []T {
+	return append([]T(nil), e.values...)
+}
+
+// Parse returns the permitted value matching the string or alias
+func (e *Enum[T]) Parse(s string) (T, bool) {
+	v, ok := e.aliases[normalizeEnum(s)]
+	return v, ok
+}
+
+// allowed returns the permitted values as strings
+func (e *Enum[T]) allowed() []string {
+	allowed := make([]string, len(e.values))
+	for i, v := range e.values {
+		allowed[i] = string(v)
+	}
+	return allowed
+}
+
+// convert converts the value to a permitted value
+func (e *Enum[T]) convert(key string, val interface{}) (T, error) {
+	var s string
+	switch v := val.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return "", newConversionError(key, val, "string", ErrWrongType)
+	}
+	if v, ok := e.Parse(s); ok {
+		return v, nil
+	}
+	convErr := newConversionError(key, val, "string", ErrEnumValue)
+	convErr.Allowed = e.allowed()
+	return "", convErr
+}
+
+// Get get param by key, return permitted value or a *ConversionError listing the permitted values
+func (e *Enum[T]) Get(p *Params, key string) (T, error) {
+	val, err := p.getValue(key, "string")
+	if err != nil {
+		return "", err
+	}
+	return e.convert(key, val)
+}
+
+// GetOk get param by key, return permitted value
+func (e *Enum[T]) GetOk(p *Params, key string) (T, bool) {
+	val, err := e.Get(p, key)
+	return val, err == nil
+}
+
+// GetSlice get param by key, return slice of permitted values or a *ConversionError
+func (e *Enum[T]) GetSlice(p *Params, key string) ([]T, error) {
+	return getSlice(p, key, "[]string", e.convert)
+}
+
+// GetSliceOk get param by key, return slice of permitted values
+func (e *Enum[T]) GetSliceOk(p *Params, key string) ([]T, bool) {
+	return sliceOk(e.GetSlice(p, key))
+}
+
+// normalizeEnum trims and lower-cases the string for matching
+func normalizeEnum(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// GetEnumE get param by key, return the permitted value it matches (ignoring case and whitespace) or a
+// *ConversionError listing the permitted values
+func (p *Params) GetEnumE(key string, allowed ...string) (string, error) {
+	return NewEnum(allowed...).Get(p, key)
+}
+
+// GetEnumOk get param by key, return the permitted value it matches (ignoring case and whitespace)
+//
+//	order, ok := params.GetEnumOk("order", "asc", "desc")
+func (p *Params) GetEnumOk(key string, allowed ...string) (string, bool) {
+	val, err := p.GetEnumE(key, allowed...)
+	return val, err == nil
+}
+
+// GetEnum get param by key, return the permitted value it matches (ignoring case and whitespace)
+func (p *Params) GetEnum(key string, allowed ...string) string {
+	val, _ := p.GetEnumOk(key, allowed...)
+	return val
+}
+
+// GetEnumSliceE get param by key, return slice of permitted values or a *ConversionError
+func (p *Params) GetEnumSliceE(key string, allowed ...string) ([]string, error) {
+	return NewEnum(allowed...).GetSlice(p, key)
+}
+
+// GetEnumSliceOk get param by key, return slice of permitted values
+func (p *Params) GetEnumSliceOk(key string, allowed ...string) ([]string, bool) {
+	return sliceOk(p.GetEnumSliceE(key, allowed...))
+}
+
+// GetEnumSlice get param by key, return slice of permitted values
+func (p *Params) GetEnumSlice(key string, allowed ...string) []string {
+	val, _ := p.GetEnumSliceOk(key, allowed...)
+	return val
+}
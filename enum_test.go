@@ -0,0 +1,105 @@
+package parameters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSortOrder is an enum type for the tests
+type testSortOrder string
+
+// TestEnum_Parse tests matching values and aliases
+func TestEnum_Parse(t *testing.T) {
+	enum := NewEnum[testSortOrder]("asc", "desc").Alias("Ascending", "asc").Alias("descending", "desc")
+
+	tests := []struct {
+		value    string
+		expected testSortOrder
+		ok       bool
+	}{
+		{"asc", "asc", true},
+		{" DESC ", "desc", true},
+		{"ascending", "asc", true},
+		{"DESCENDING\t", "desc", true},
+		{"up", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			val, ok := enum.Parse(tt.value)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, val)
+		})
+	}
+	assert.Equal(t, []testSortOrder{"asc", "desc"}, enum.Values())
+}
+
+// TestEnum_Get tests the enum getters
+func TestEnum_Get(t *testing.T) {
+	enum := NewEnum[testSortOrder]("asc", "desc").Alias("ascending", "asc")
+	params := &Params{Values: map[string]interface{}{
+		"order":   " ASC",
+		"alias":   []byte("Ascending"),
+		"invalid": "up",
+		"number":  1,
+		"orders":  "asc, DESC",
+		"list":    []interface{}{"desc", "ascending"},
+		"mixed":   []interface{}{"asc", "sideways"},
+	}}
+
+	val, err := enum.Get(params, "order")
+	require.NoError(t, err)
+	assert.Equal(t, testSortOrder("asc"), val)
+
+	val, ok := enum.GetOk(params, "alias")
+	assert.True(t, ok)
+	assert.Equal(t, testSortOrder("asc"), val)
+
+	_, err = enum.Get(params, "invalid")
+	require.ErrorIs(t, err, ErrEnumValue)
+	require.ErrorIs(t, err, ErrConversionFailed)
+	assert.Equal(t, "invalid must be one of: asc, desc", err.Error())
+
+	_, err = enum.Get(params, "number")
+	require.ErrorIs(t, err, ErrWrongType)
+
+	_, err = enum.Get(params, "missing")
+	require.ErrorIs(t, err, ErrParamNotFound)
+
+	values, err := enum.GetSlice(params, "orders")
+	require.NoError(t, err)
+	assert.Equal(t, []testSortOrder{"asc", "desc"}, values)
+
+	values, ok = enum.GetSliceOk(params, "list")
+	assert.True(t, ok)
+	assert.Equal(t, []testSortOrder{"desc", "asc"}, values)
+
+	_, err = enum.GetSlice(params, "mixed")
+	require.ErrorIs(t, err, ErrEnumValue)
+	assert.Equal(t, "mixed.1 must be one of: asc, desc", err.Error())
+}
+
+// TestParams_GetEnum tests the string enum getters
+func TestParams_GetEnum(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"status":   "Active ",
+		"invalid":  "deleted",
+		"statuses": "active,pending",
+	}}
+
+	assert.Equal(t, "active", params.GetEnum("status", "active", "pending"))
+
+	val, ok := params.GetEnumOk("invalid", "active", "pending")
+	assert.False(t, ok)
+	assert.Empty(t, val)
+
+	_, err := params.GetEnumE("invalid", "active", "pending")
+	assert.EqualError(t, err, "invalid must be one of: active, pending")
+
+	assert.Equal(t, []string{"active", "pending"}, params.GetEnumSlice("statuses", "active", "pending"))
+
+	_, ok = params.GetEnumSliceOk("statuses", "active")
+	assert.False(t, ok)
+}
@@ -0,0 +1,196 @@
+package parameters
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Constants for file names
+const (
+	// DefaultFilename is used when nothing is left of a file name after sanitizing it
+	DefaultFilename = "unnamed"
+
+	// maxFilenameLength is the maximum length of a sanitized file name in bytes
+	maxFilenameLength = 255
+)
+
+// ErrFileNotFound is returned when there is no uploaded file for the key
+var ErrFileNotFound = errors.New("parameters: file not found")
+
+// reservedFilenames are the device names reserved on Windows
+var reservedFilenames = map[string]struct{}{
+	"CON": {}, "PRN": {}, "AUX": {}, "NUL": {},
+	"COM1": {}, "COM2": {}, "COM3": {}, "COM4": {}, "COM5": {}, "COM6": {}, "COM7": {}, "COM8": {}, "COM9": {},
+	"LPT1": {}, "LPT2": {}, "LPT3": {}, "LPT4": {}, "LPT5": {}, "LPT6": {}, "LPT7": {}, "LPT8": {}, "LPT9": {},
+}
+
+// FileInfo is the inspected information of an uploaded file
+type FileInfo struct {
+	// Header is the uploaded file
+	Header *multipart.FileHeader
+
+	// Filename is the sanitized file name, safe to use as a path element
+	Filename string
+
+	// OriginalFilename is the file name as sent by the client
+	OriginalFilename string
+
+	// DeclaredType is the Content-Type sent by the client
+	DeclaredType string
+
+	// ContentType is the media type (without parameters) detected from the first bytes of the file, as
+	// matched by FilePolicy.AllowedTypes
+	ContentType string
+
+	// Size is the size of the file in bytes
+	Size int64
+
+	// SHA256 is the hex encoded SHA-256 checksum of the file
+	SHA256 string
+}
+
+// GetFileInfo get param by key, return the inspected (first) file uploaded with the key.
+// The file is read once and the result is cached on the params.
+func (p *Params) GetFileInfo(key string) (*FileInfo, error) {
+	fh, ok := p.GetFileOk(key)
+	if !ok {
+		return nil, ErrFileNotFound
+	}
+	return p.fileInfo(fh)
+}
+
+// GetFilesInfo get param by key, return the inspected files uploaded with the key.
+// The files are read once and the results are cached on the params.
+func (p *Params) GetFilesInfo(key string) ([]*FileInfo, error) {
+	files, ok := p.GetFilesOk(key)
+	if !ok {
+		return nil, ErrFileNotFound
+	}
+	infos := make([]*FileInfo, 0, len(files))
+	for _, fh := range files {
+		info, err := p.fileInfo(fh)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// fileInfo returns the cached info of the file or inspects it
+func (p *Params) fileInfo(fh *multipart.FileHeader) (*FileInfo, error) {
+	p.fileInfosMu.Lock()
+	info, ok := p.fileInfos[fh]
+	p.fileInfosMu.Unlock()
+	if ok {
+		return info, nil
+	}
+
+	// The file is read without holding the lock, the first result stored wins
+	info, err := inspectFile(fh)
+	if err != nil {
+		return nil, err
+	}
+	p.fileInfosMu.Lock()
+	defer p.fileInfosMu.Unlock()
+	if cached, exists := p.fileInfos[fh]; exists {
+		return cached, nil
+	}
+	if p.fileInfos == nil {
+		p.fileInfos = make(map[*multipart.FileHeader]*FileInfo)
+	}
+	p.fileInfos[fh] = info
+	return info, nil
+}
+
+// inspectFile reads the file to detect the content type and calculate the checksum
+func inspectFile(fh *multipart.FileHeader) (*FileInfo, error) {
+	file, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	sniff := make([]byte, sniffLength)
+	n, err := io.ReadFull(file, sniff)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	_, _ = hash.Write(sniff[:n])
+	rest, err := io.Copy(hash, file)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileInfo{
+		Header:           fh,
+		Filename:         SanitizeFilename(fh.Filename),
+		OriginalFilename: fh.Filename,
+		DeclaredType:     fh.Header.Get("Content-Type"),
+		ContentType:      detectMediaType(sniff[:n]),
+		Size:             int64(n) + rest,
+		SHA256:           hex.EncodeToString(hash.Sum(nil)),
+	}, nil
+}
+
+// SanitizeFilename makes a client supplied file name safe to use as a single path element.
+// Directories, control and format characters (such as U+202E), characters reserved on common file systems, leading and
+// trailing dots and spaces are removed, and reserved device names (CON, NUL, ...) are prefixed.
+//
+//	../../etc/passwd -> passwd
+//	C:\Users\me\CON.txt -> _CON.txt
+func SanitizeFilename(name string) string {
+	// Remove any directory, using both separators
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+
+	name = strings.Map(func(r rune) rune {
+		if r == utf8.RuneError || unicode.IsControl(r) || unicode.Is(unicode.Cf, r) || strings.ContainsRune(`<>:"|?*`, r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.Trim(name, ". ")
+
+	// Windows reserves the device names with any extension ("CON.tar.gz")
+	base, _, _ := strings.Cut(name, ".")
+	if _, reserved := reservedFilenames[strings.ToUpper(strings.TrimRight(base, " "))]; reserved {
+		name = "_" + name
+	}
+
+	if len(name) > maxFilenameLength {
+		ext := filepath.Ext(name)
+		if len(ext) >= maxFilenameLength {
+			ext = ""
+		}
+		name = truncateUTF8(strings.TrimSuffix(name, ext), maxFilenameLength-len(ext)) + ext
+	}
+
+	if name == "" {
+		return DefaultFilename
+	}
+	return name
+}
+
+// truncateUTF8 truncates the string to at most n bytes without splitting a rune
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,123 @@
+package parameters
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParams_GetFileInfo tests the GetFileInfo method
+func TestParams_GetFileInfo(t *testing.T) {
+	content := append(append([]byte{}, testPNG...), []byte(strings.Repeat("x", 1000))...)
+	req := newMultipartRequest(t, map[string]string{testNameParam: "Alice"},
+		testFile{field: "avatar", filename: "../../etc/avatar.png", content: content},
+	)
+	params := ParseParams(req)
+
+	info, err := params.GetFileInfo("avatar")
+	require.NoError(t, err)
+	require.NotNil(t, info)
+
+	sum := sha256.Sum256(content)
+	assert.Equal(t, "image/png", info.ContentType)
+	assert.Equal(t, "application/octet-stream", info.DeclaredType)
+	assert.Equal(t, int64(len(content)), info.Size)
+	assert.Equal(t, hex.EncodeToString(sum[:]), info.SHA256)
+	assert.Equal(t, "avatar.png", info.Filename)
+	assert.NotNil(t, info.Header)
+
+	// The result is cached
+	cached, err := params.GetFileInfo("avatar")
+	require.NoError(t, err)
+	assert.Same(t, info, cached)
+
+	_, err = params.GetFileInfo(testNameParam)
+	require.ErrorIs(t, err, ErrFileNotFound)
+
+	_, err = params.GetFileInfo("missing")
+	require.ErrorIs(t, err, ErrFileNotFound)
+}
+
+// TestParams_GetFilesInfo tests the GetFilesInfo method
+func TestParams_GetFilesInfo(t *testing.T) {
+	req := newMultipartRequest(t, nil,
+		testFile{field: "docs", filename: "a.txt", content: []byte("hello")},
+		testFile{field: "docs", filename: "b.txt", content: []byte("world")},
+	)
+	params := ParseParams(req)
+
+	infos, err := params.GetFilesInfo("docs")
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	assert.Equal(t, "a.txt", infos[0].Filename)
+	assert.Equal(t, "b.txt", infos[1].Filename)
+	assert.Equal(t, "text/plain", infos[1].ContentType)
+	assert.Equal(t, int64(5), infos[1].Size)
+
+	_, err = params.GetFilesInfo("missing")
+	require.ErrorIs(t, err, ErrFileNotFound)
+}
+
+// TestParams_GetFileInfoConcurrent tests inspecting the files from several goroutines
+func TestParams_GetFileInfoConcurrent(t *testing.T) {
+	req := newMultipartRequest(t, nil,
+		testFile{field: "docs", filename: "a.txt", content: []byte("hello")},
+		testFile{field: "docs", filename: "b.txt", content: []byte("world")},
+	)
+	params := ParseParams(req)
+
+	var wg sync.WaitGroup
+	infos := make([][]*FileInfo, 8)
+	for i := range infos {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			infos[i], _ = params.GetFilesInfo("docs")
+		}()
+	}
+	wg.Wait()
+
+	for _, got := range infos {
+		require.Len(t, got, 2)
+		assert.Same(t, infos[0][0], got[0])
+		assert.Same(t, infos[0][1], got[1])
+	}
+}
+
+// TestSanitizeFilename tests the SanitizeFilename function
+func TestSanitizeFilename(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "plain", input: "report.pdf", expected: "report.pdf"},
+		{name: "unix traversal", input: "../../etc/passwd", expected: "passwd"},
+		{name: "windows traversal", input: `..\..\windows\win.ini`, expected: "win.ini"},
+		{name: "control characters", input: "re\x00po\nrt\x1b.pdf", expected: "report.pdf"},
+		{name: "reserved characters", input: `a<b>c:d"e|f?g*.txt`, expected: "abcdefg.txt"},
+		{name: "leading and trailing dots", input: "..hidden.txt. ", expected: "hidden.txt"},
+		{name: "reserved device name", input: "CON", expected: "_CON"},
+		{name: "reserved device name with extension", input: "nul.txt", expected: "_nul.txt"},
+		{name: "reserved device name with number", input: "com1.log", expected: "_com1.log"},
+		{name: "reserved device name with extensions", input: "CON.tar.gz", expected: "_CON.tar.gz"},
+		{name: "format characters", input: "invoice\u202efdp.exe", expected: "invoicefdp.exe"},
+		{name: "not a reserved name", input: "console.txt", expected: "console.txt"},
+		{name: "only dots", input: "..", expected: DefaultFilename},
+		{name: "empty", input: "", expected: DefaultFilename},
+		{name: "unicode", input: "résumé 2024.pdf", expected: "résumé 2024.pdf"},
+		{name: "too long", input: strings.Repeat("a", 300) + ".txt", expected: strings.Repeat("a", 251) + ".txt"},
+		{name: "too long multibyte", input: strings.Repeat("é", 200), expected: strings.Repeat("é", 127)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, SanitizeFilename(tt.input))
+		})
+	}
+}
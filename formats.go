@@ -0,0 +1,269 @@
+package parameters
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// FormatTag is the struct tag used by Imbue to validate string fields (e.g. `format:"email"`)
+const FormatTag = "format"
+
+// The built-in formats
+const (
+	FormatEmail     = "email"
+	FormatHostname  = "hostname"
+	FormatPhoneE164 = "phone_e164"
+	FormatSlug      = "slug"
+	FormatHexColor  = "hex_color"
+)
+
+// ErrUnknownFormat is returned when no format is registered with the name
+var ErrUnknownFormat = errors.New("parameters: unknown format")
+
+// Patterns of the built-in formats
+var (
+	emailLocalPattern = regexp.MustCompile("^[A-Za-z0-9!#$%&'*+/=?^_`{|}~-]+(?:\\.[A-Za-z0-9!#$%&'*+/=?^_`{|}~-]+)*$")
+	hostLabelPattern  = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?$`)
+	slugPattern       = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+	hexColorPattern   = regexp.MustCompile(`^#?([0-9a-f]{3}|[0-9a-f]{6})$`)
+	phoneDigits       = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+)
+
+// FormatFunc normalizes and validates a string, returning an error if the string is invalid
+type FormatFunc func(s string) (string, error)
+
+// formatEntry is a registered format
+type formatEntry struct {
+	fn          FormatFunc
+	description string
+}
+
+// The formats by name
+var (
+	formatsMu sync.RWMutex
+	formats   = map[string]formatEntry{
+		FormatEmail:     {fn: NormalizeEmail, description: "an email address"},
+		FormatHostname:  {fn: NormalizeHostname, description: "a hostname"},
+		FormatPhoneE164: {fn: NormalizePhoneE164, description: "a phone number in E.164 format"},
+		FormatSlug:      {fn: NormalizeSlug, description: "a slug"},
+		FormatHexColor:  {fn: NormalizeHexColor, description: "a hex color"},
+	}
+)
+
+// RegisterFormat registers a format for GetFormat and the format struct tag of Imbue, the description is
+// used in error messages (e.g. "an order number")
+func RegisterFormat(name, description string, fn FormatFunc) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	formats[name] = formatEntry{fn: fn, description: description}
+}
+
+// ValidateFormatTags returns an error if a string field of the struct (or pointer to a struct) has a format
+// tag with an unknown format, Imbue skips such fields so run it once at startup to catch typos
+//
+//	if err := parameters.ValidateFormatTags(&SignupForm{}); err != nil {
+//		log.Fatal(err)
+//	}
+func ValidateFormatTags(obj interface{}) error {
+	typeOfObject := reflect.TypeOf(obj)
+	for typeOfObject != nil && typeOfObject.Kind() == reflect.Ptr {
+		typeOfObject = typeOfObject.Elem()
+	}
+	if typeOfObject == nil || typeOfObject.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < typeOfObject.NumField(); i++ {
+		field := typeOfObject.Field(i)
+		if format, hasFormat := field.Tag.Lookup(FormatTag); hasFormat && field.Type.Kind() == reflect.String {
+			if _, ok := lookupFormat(format); !ok {
+				return fmt.Errorf("%w: %q on field %s", ErrUnknownFormat, format, field.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// lookupFormat returns the format with the name
+func lookupFormat(name string) (formatEntry, bool) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	entry, ok := formats[name]
+	return entry, ok
+}
+
+// invalidFormat returns the error for a string that does not match the format
+func invalidFormat(format, s string) error {
+	return fmt.Errorf("%w: invalid %s %q", ErrParse, format, s)
+}
+
+// NormalizeEmail validates an email address ("local@domain") and lower-cases the domain
+func NormalizeEmail(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	at := strings.LastIndexByte(s, '@')
+	if at < 1 || len(s) > 254 {
+		return "", invalidFormat(FormatEmail, s)
+	}
+	local := s[:at]
+	if len(local) > 64 || !emailLocalPattern.MatchString(local) {
+		return "", invalidFormat(FormatEmail, s)
+	}
+	domain, err := NormalizeHostname(s[at+1:])
+	if err != nil || !strings.Contains(domain, ".") {
+		return "", invalidFormat(FormatEmail, s)
+	}
+	return local + "@" + domain, nil
+}
+
+// NormalizeHostname validates a DNS hostname and lower-cases it, removing a trailing dot
+func NormalizeHostname(s string) (string, error) {
+	host := strings.TrimSuffix(strings.ToLower(strings.TrimSpace(s)), ".")
+	if host == "" || len(host) > 253 {
+		return "", invalidFormat(FormatHostname, s)
+	}
+	labels := strings.Split(host, ".")
+	for _, label := range labels {
+		if !hostLabelPattern.MatchString(label) {
+			return "", invalidFormat(FormatHostname, s)
+		}
+	}
+
+	// A numeric top-level domain would be an IP address
+	if strings.Trim(labels[len(labels)-1], "0123456789") == "" {
+		return "", invalidFormat(FormatHostname, s)
+	}
+	return host, nil
+}
+
+// NormalizePhoneE164 removes the formatting of a phone number in international format
+// ("+1 (555) 010-0000" or "00 1 555 0100000") and validates it as E.164 ("+15550100000")
+func NormalizePhoneE164(s string) (string, error) {
+	phone := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')', '/', '\t':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(s))
+	if strings.HasPrefix(phone, "00") {
+		phone = "+" + phone[2:]
+	}
+	if !phoneDigits.MatchString(phone) {
+		return "", invalidFormat(FormatPhoneE164, s)
+	}
+	return phone, nil
+}
+
+// NormalizeSlug lower-cases and validates a slug ("my-first-post")
+func NormalizeSlug(s string) (string, error) {
+	slug := strings.ToLower(strings.TrimSpace(s))
+	if !slugPattern.MatchString(slug) {
+		return "", invalidFormat(FormatSlug, s)
+	}
+	return slug, nil
+}
+
+// NormalizeHexColor validates a hex color with or without "#" and returns it as "#rrggbb"
+func NormalizeHexColor(s string) (string, error) {
+	matches := hexColorPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(s)))
+	if matches == nil {
+		return "", invalidFormat(FormatHexColor, s)
+	}
+	hex := matches[1]
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	return "#" + hex, nil
+}
+
+// GetFormatE get param by key, return string normalized by the format or a *ConversionError
+func (p *Params) GetFormatE(key, format string) (string, error) {
+	entry, ok := lookupFormat(format)
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+	val, err := p.getValue(key, format)
+	if err != nil {
+		return "", err
+	}
+
+	var str string
+	switch v := val.(type) {
+	case string:
+		str = v
+	case []byte:
+		str = string(v)
+	default:
+		return "", newConversionError(key, val, format, ErrWrongType)
+	}
+	normalized, err := entry.fn(str)
+	if err != nil {
+		return "", newConversionError(key, val, format, ErrParse)
+	}
+	return normalized, nil
+}
+
+// GetFormatOk get param by key, return string normalized by the format
+func (p *Params) GetFormatOk(key, format string) (string, bool) {
+	val, err := p.GetFormatE(key, format)
+	return val, err == nil
+}
+
+// GetFormat get param by key, return string normalized by the format
+func (p *Params) GetFormat(key, format string) string {
+	val, _ := p.GetFormatOk(key, format)
+	return val
+}
+
+// GetEmailOk get param by key, return email address with a lower-case domain
+func (p *Params) GetEmailOk(key string) (string, bool) {
+	return p.GetFormatOk(key, FormatEmail)
+}
+
+// GetEmail get param by key, return email address with a lower-case domain
+func (p *Params) GetEmail(key string) string {
+	return p.GetFormat(key, FormatEmail)
+}
+
+// GetHostnameOk get param by key, return lower-case hostname
+func (p *Params) GetHostnameOk(key string) (string, bool) {
+	return p.GetFormatOk(key, FormatHostname)
+}
+
+// GetHostname get param by key, return lower-case hostname
+func (p *Params) GetHostname(key string) string {
+	return p.GetFormat(key, FormatHostname)
+}
+
+// GetPhoneE164Ok get param by key, return phone number in E.164 format ("+15550100000")
+func (p *Params) GetPhoneE164Ok(key string) (string, bool) {
+	return p.GetFormatOk(key, FormatPhoneE164)
+}
+
+// GetPhoneE164 get param by key, return phone number in E.164 format ("+15550100000")
+func (p *Params) GetPhoneE164(key string) string {
+	return p.GetFormat(key, FormatPhoneE164)
+}
+
+// GetSlugOk get param by key, return lower-case slug
+func (p *Params) GetSlugOk(key string) (string, bool) {
+	return p.GetFormatOk(key, FormatSlug)
+}
+
+// GetSlug get param by key, return lower-case slug
+func (p *Params) GetSlug(key string) string {
+	return p.GetFormat(key, FormatSlug)
+}
+
+// GetHexColorOk get param by key, return hex color as "#rrggbb"
+func (p *Params) GetHexColorOk(key string) (string, bool) {
+	return p.GetFormatOk(key, FormatHexColor)
+}
+
+// GetHexColor get param by key, return hex color as "#rrggbb"
+func (p *Params) GetHexColor(key string) string {
+	return p.GetFormat(key, FormatHexColor)
+}
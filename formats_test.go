@@ -0,0 +1,176 @@
+package parameters
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNormalizeFormats tests the built-in formats
+func TestNormalizeFormats(t *testing.T) {
+	tests := []struct {
+		name     string
+		fn       FormatFunc
+		value    string
+		expected string
+		ok       bool
+	}{
+		{"email", NormalizeEmail, " John.Doe+tag@Example.COM ", "John.Doe+tag@example.com", true},
+		{"email subdomain", NormalizeEmail, "a@mail.example.co.uk", "a@mail.example.co.uk", true},
+		{"email without domain dot", NormalizeEmail, "a@localhost", "", false},
+		{"email without local", NormalizeEmail, "@example.com", "", false},
+		{"email double dot", NormalizeEmail, "a..b@example.com", "", false},
+		{"email two at", NormalizeEmail, "a@b@example.com", "", false},
+		{"email display name", NormalizeEmail, "John <john@example.com>", "", false},
+		{"email long local", NormalizeEmail, strings.Repeat("a", 65) + "@example.com", "", false},
+		{"hostname", NormalizeHostname, "API.Example.com.", "api.example.com", true},
+		{"hostname single label", NormalizeHostname, "localhost", "localhost", true},
+		{"hostname leading hyphen", NormalizeHostname, "-a.example.com", "", false},
+		{"hostname underscore", NormalizeHostname, "a_b.example.com", "", false},
+		{"hostname empty label", NormalizeHostname, "a..example.com", "", false},
+		{"hostname ip", NormalizeHostname, "192.168.0.1", "", false},
+		{"hostname long label", NormalizeHostname, strings.Repeat("a", 64) + ".com", "", false},
+		{"phone", NormalizePhoneE164, "+1 (555) 010-0000", "+15550100000", true},
+		{"phone with 00", NormalizePhoneE164, "00 31 6 1234 5678", "+31612345678", true},
+		{"phone dots", NormalizePhoneE164, "+44.20.7946.0000", "+442079460000", true},
+		{"phone without plus", NormalizePhoneE164, "5550100000", "", false},
+		{"phone leading zero", NormalizePhoneE164, "+0123456789", "", false},
+		{"phone too long", NormalizePhoneE164, "+1234567890123456", "", false},
+		{"phone letters", NormalizePhoneE164, "+1 555 CALL NOW", "", false},
+		{"slug", NormalizeSlug, " My-First-Post ", "my-first-post", true},
+		{"slug double hyphen", NormalizeSlug, "my--post", "", false},
+		{"slug trailing hyphen", NormalizeSlug, "post-", "", false},
+		{"slug space", NormalizeSlug, "my post", "", false},
+		{"hex color", NormalizeHexColor, "#FF8800", "#ff8800", true},
+		{"hex color short", NormalizeHexColor, "f80", "#ff8800", true},
+		{"hex color invalid", NormalizeHexColor, "#ff880", "", false},
+		{"hex color name", NormalizeHexColor, "red", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized, err := tt.fn(tt.value)
+			if !tt.ok {
+				require.ErrorIs(t, err, ErrParse)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, normalized)
+		})
+	}
+}
+
+// TestParams_GetFormats tests the format getters
+func TestParams_GetFormats(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"email":   "Jane@Example.com",
+		"host":    "Example.com",
+		"phone":   "+1 555-010-0000",
+		"slug":    "Hello-World",
+		"color":   "#ABC",
+		"invalid": "not valid",
+		"number":  42,
+	}}
+
+	assert.Equal(t, "Jane@example.com", params.GetEmail("email"))
+	assert.Equal(t, "example.com", params.GetHostname("host"))
+	assert.Equal(t, "+15550100000", params.GetPhoneE164("phone"))
+	assert.Equal(t, "hello-world", params.GetSlug("slug"))
+	assert.Equal(t, "#aabbcc", params.GetHexColor("color"))
+
+	for _, get := range []func(string) (string, bool){
+		params.GetEmailOk, params.GetHostnameOk, params.GetPhoneE164Ok, params.GetSlugOk, params.GetHexColorOk,
+	} {
+		_, ok := get("invalid")
+		assert.False(t, ok)
+		_, ok = get("missing")
+		assert.False(t, ok)
+	}
+
+	_, err := params.GetFormatE("invalid", FormatEmail)
+	require.ErrorIs(t, err, ErrParse)
+	assert.Equal(t, "invalid must be an email address", err.Error())
+
+	_, err = params.GetFormatE("number", FormatPhoneE164)
+	require.ErrorIs(t, err, ErrWrongType)
+	assert.Equal(t, "number must be a phone number in E.164 format", err.Error())
+
+	_, err = params.GetFormatE("email", "unknown")
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+// TestRegisterFormat tests registering a custom format
+func TestRegisterFormat(t *testing.T) {
+	RegisterFormat("order_number", "an order number", func(s string) (string, error) {
+		s = strings.ToUpper(strings.TrimSpace(s))
+		if !strings.HasPrefix(s, "ORD-") {
+			return "", fmt.Errorf("%w: order number", ErrParse)
+		}
+		return s, nil
+	})
+	t.Cleanup(func() {
+		formatsMu.Lock()
+		delete(formats, "order_number")
+		formatsMu.Unlock()
+	})
+
+	params := &Params{Values: map[string]interface{}{"order": "ord-42", "other": "42"}}
+	assert.Equal(t, "ORD-42", params.GetFormat("order", "order_number"))
+
+	_, err := params.GetFormatE("other", "order_number")
+	assert.Equal(t, "other must be an order number", err.Error())
+}
+
+// TestImbue_Format tests that Imbue normalizes string fields with a format tag
+func TestImbue_Format(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"email":   "Jane@Example.com",
+		"website": "Example.com",
+		"color":   "not a color",
+		"name":    "Jane",
+	}}
+
+	type testType struct {
+		Email   string `format:"email"`
+		Website string `format:"hostname"`
+		Color   string `format:"hex_color"`
+		Name    string
+	}
+
+	obj := testType{Color: "#000000"}
+	params.Imbue(&obj)
+
+	assert.Equal(t, "Jane@example.com", obj.Email)
+	assert.Equal(t, "example.com", obj.Website)
+	assert.Empty(t, obj.Color)
+	assert.Equal(t, "Jane", obj.Name)
+}
+
+// TestImbue_UnknownFormat tests that Imbue skips fields with an unknown format and ValidateFormatTags reports them
+func TestImbue_UnknownFormat(t *testing.T) {
+	type testType struct {
+		Email string `format:"e-mail"`
+		Name  string
+	}
+
+	params := &Params{Values: map[string]interface{}{"email": "jane@example.com", "name": "Jane"}}
+	obj := testType{Email: "unchanged"}
+	assert.NotPanics(t, func() {
+		params.Imbue(&obj)
+	})
+	assert.Equal(t, "unchanged", obj.Email)
+	assert.Equal(t, "Jane", obj.Name)
+
+	err := ValidateFormatTags(&testType{})
+	require.ErrorIs(t, err, ErrUnknownFormat)
+	assert.Equal(t, `parameters: unknown format: "e-mail" on field Email`, err.Error())
+
+	type validType struct {
+		Email string `format:"email"`
+		Count int    `format:"e-mail"`
+	}
+	require.NoError(t, ValidateFormatTags(validType{}))
+	require.NoError(t, ValidateFormatTags("not a struct"))
+}
@@ -218,10 +218,20 @@ func FuzzTypeConversions(f *testing.F) {
 		_, _ = params.GetInt64Ok("test")
 		_ = params.GetUint64("test")
 		_, _ = params.GetUint64Ok("test")
+		_ = params.GetUint("test")
+		_, _ = params.GetUintOk("test")
+		_ = params.GetUint8("test")
+		_, _ = params.GetUint8Ok("test")
+		_ = params.GetUint16("test")
+		_, _ = params.GetUint16Ok("test")
+		_ = params.GetUint32("test")
+		_, _ = params.GetUint32Ok("test")
 
 		// Test float conversions
 		_ = params.GetFloat("test")
 		_, _ = params.GetFloatOk("test")
+		_ = params.GetFloat32("test")
+		_, _ = params.GetFloat32Ok("test")
 
 		// Test boolean conversions
 		_ = params.GetBool("test")
@@ -236,6 +246,12 @@ func FuzzTypeConversions(f *testing.F) {
 		_, _ = params.GetStringSliceOk("test")
 		_ = params.GetUint64Slice("test")
 		_, _ = params.GetUint64SliceOk("test")
+		_ = params.GetInt32Slice("test")
+		_, _ = params.GetInt32SliceOk("test")
+		_ = params.GetInt64Slice("test")
+		_, _ = params.GetInt64SliceOk("test")
+		_ = params.GetBoolSlice("test")
+		_, _ = params.GetBoolSliceOk("test")
 
 		// Test string conversion
 		_ = params.GetString("test")
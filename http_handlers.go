@@ -60,25 +60,25 @@ func GeneralJSONResponse(fn http.HandlerFunc) httprouter.Handle {
 	return EnableGZIP(JSONResp(MakeHTTPRouterParsedReq(CORSHeaders(fn))))
 }
 
-// filterReplace is the value to replace filtered keys with
-var filterReplace = [...]string{FilteredValue}
-
 // FilteredKeys is a lower case array of keys to filter when logging
 var FilteredKeys []string
 
 // FilterMap will filter the parameters and not log parameters with sensitive data.
-// To add more parameters to filter, add the key to the FilteredKeys array
+// To add more parameters to filter, add the key to the FilteredKeys array.
+// The values are deep copies, so changing the parameters never changes the filtered copy
 func FilterMap(params *Params) *Params {
+	params.ensureParsed()
+
 	var filtered Params
 	filtered.Values = make(map[string]interface{}, len(params.Values))
 
 	for k, v := range params.Values {
 		if contains(FilteredKeys, k) {
-			filtered.Values[k] = filterReplace[:]
+			filtered.Values[k] = []string{FilteredValue}
 		} else if b, ok := v.([]byte); ok {
 			filtered.Values[k] = string(b)
 		} else {
-			filtered.Values[k] = v
+			filtered.Values[k] = deepCopy(v)
 		}
 	}
 	return &filtered
@@ -172,6 +172,27 @@ func TestFilterMap(t *testing.T) {
 	}
 }
 
+// TestFilterMap_DeepCopy tests that the filtered copy does not share values with the parameters
+func TestFilterMap_DeepCopy(t *testing.T) {
+	FilteredKeys = []string{"password"}
+
+	params := &Params{Values: map[string]interface{}{
+		"password": "secret",
+		"billing":  map[string]interface{}{"zip": "10115"},
+		"tags":     []interface{}{"a"},
+	}}
+	filtered := FilterMap(params)
+
+	require.NoError(t, params.Set("billing.zip", "10117"))
+	params.Values["tags"].([]interface{})[0] = "b"
+	assert.Equal(t, "10115", filtered.GetString("billing.zip"))
+	assert.Equal(t, []string{"a"}, filtered.GetStringSlice("tags"))
+
+	// Every filtered value is a separate slice
+	filtered.Values["password"].([]string)[0] = "changed"
+	assert.Equal(t, []string{FilteredValue}, FilterMap(params).Values["password"])
+}
+
 // TestGeneralResponse tests the GeneralResponse function
 func TestGeneralResponse(t *testing.T) {
 	t.Run("Without GZIP", func(t *testing.T) {
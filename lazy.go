@@ -0,0 +1,89 @@
+package parameters
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gorilla/mux"
+	"github.com/julienschmidt/httprouter"
+)
+
+// lazyLoader defers parsing the request until the parameters are first accessed
+type lazyLoader struct {
+	once   sync.Once
+	parsed atomic.Bool
+	path   map[string]interface{}
+	req    *http.Request
+}
+
+// ensureParsed parses the request (only once) if the params were created in lazy mode
+func (p *Params) ensureParsed() {
+	if p == nil || p.lazy == nil {
+		return
+	}
+	p.lazy.once.Do(func() {
+		parsed := parseRequest(p.lazy.req, DefaultLimits)
+
+		// Path parameters take precedence over the parsed values
+		for k, v := range p.lazy.path {
+			parsed.Values[k] = v
+		}
+		p.isBinary = parsed.isBinary
+		p.parseErr = parsed.parseErr
+		p.attachments = parsed.attachments
+		p.Values = parsed.Values
+		p.lazy.req = nil
+		p.lazy.parsed.Store(true)
+	})
+}
+
+// IsParsed will return false if the params are lazy and the request has not been parsed yet
+func (p *Params) IsParsed() bool {
+	return p.lazy == nil || p.lazy.parsed.Load()
+}
+
+// newLazyParams creates lazy params for the request and adds them to the request context
+func newLazyParams(req *http.Request) (*Params, *http.Request) {
+	path := make(map[string]interface{})
+	params := &Params{
+		lazy:   &lazyLoader{path: path},
+		Values: path,
+	}
+	req = req.WithContext(context.WithValue(req.Context(), ParamsKeyName, params))
+	params.lazy.req = req
+	return params, req
+}
+
+// MakeLazyParsedReq make lazy parsed request, the body is only read and decoded
+// when a parameter is first accessed. Route variables are available immediately.
+func MakeLazyParsedReq(fn http.HandlerFunc) http.HandlerFunc {
+	return func(rw http.ResponseWriter, r *http.Request) {
+		if _, exists := r.Context().Value(ParamsKeyName).(*Params); exists {
+			fn(rw, r)
+			return
+		}
+		var params *Params
+		params, r = newLazyParams(r)
+		for k, v := range mux.Vars(r) {
+			params.Values[k] = pathValue(k, v)
+		}
+		fn(rw, r)
+	}
+}
+
+// MakeHTTPRouterLazyParsedReq make lazy http router parsed request, the body is only
+// read and decoded when a parameter is first accessed. Path parameters are available immediately.
+func MakeHTTPRouterLazyParsedReq(fn httprouter.Handle) httprouter.Handle {
+	return func(rw http.ResponseWriter, r *http.Request, p httprouter.Params) {
+		params, exists := r.Context().Value(ParamsKeyName).(*Params)
+		if !exists {
+			params, r = newLazyParams(r)
+		}
+		for _, param := range p {
+			params.Values[param.Key] = pathValue(param.Key, param.Value)
+		}
+		fn(rw, r, p)
+	}
+}
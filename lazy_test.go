@@ -0,0 +1,147 @@
+package parameters
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingReader counts the reads of the request body
+type countingReader struct {
+	io.Reader
+	mu    sync.Mutex
+	reads int
+}
+
+// Read will read and count
+func (c *countingReader) Read(b []byte) (int, error) {
+	c.mu.Lock()
+	c.reads++
+	c.mu.Unlock()
+	return c.Reader.Read(b)
+}
+
+// TestMakeLazyParsedReq tests the MakeLazyParsedReq function
+func TestMakeLazyParsedReq(t *testing.T) {
+	t.Run("body is not read until a parameter is accessed", func(t *testing.T) {
+		body := &countingReader{Reader: strings.NewReader(`{"name":"Alice","age":30}`)}
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/users", body)
+		req.Header.Set("Content-Type", "application/json")
+		req.ContentLength = int64(len(`{"name":"Alice","age":30}`))
+
+		handler := MakeLazyParsedReq(func(_ http.ResponseWriter, r *http.Request) {
+			params := GetParams(r)
+			require.NotNil(t, params)
+			assert.False(t, params.IsParsed())
+			assert.Equal(t, 0, body.reads)
+
+			assert.Equal(t, "Alice", params.GetString(testNameParam))
+			assert.Equal(t, 30, params.GetInt("age"))
+			assert.True(t, params.IsParsed())
+			assert.Positive(t, body.reads)
+
+			// The body is restored for other readers
+			restored, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"name":"Alice","age":30}`, string(restored))
+		})
+		handler(httptest.NewRecorder(), req)
+	})
+
+	t.Run("handler streams the body itself", func(t *testing.T) {
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/upload", strings.NewReader("raw data"))
+		req.Header.Set("Content-Type", "application/octet-stream")
+
+		handler := MakeLazyParsedReq(func(_ http.ResponseWriter, r *http.Request) {
+			data, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			assert.Equal(t, "raw data", string(data))
+			assert.False(t, GetParams(r).IsParsed())
+		})
+		handler(httptest.NewRecorder(), req)
+	})
+
+	t.Run("mux variables are available without parsing", func(t *testing.T) {
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/users/42?filter=active", strings.NewReader(`{"user_id":"body"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req = mux.SetURLVars(req, map[string]string{"user_id": "42"})
+
+		handler := MakeLazyParsedReq(func(_ http.ResponseWriter, r *http.Request) {
+			params := GetParams(r)
+			assert.Equal(t, uint64(42), params.GetUint64("user_id"))
+			assert.False(t, params.IsParsed())
+
+			assert.Equal(t, "active", params.GetString("filter"))
+			assert.True(t, params.IsParsed())
+			assert.Equal(t, uint64(42), params.GetUint64("user_id"))
+		})
+		handler(httptest.NewRecorder(), req)
+	})
+
+	t.Run("existing params are reused", func(t *testing.T) {
+		req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "/test?test=true", nil)
+		existing := ParseParams(req)
+		req = req.WithContext(context.WithValue(req.Context(), ParamsKeyName, existing))
+
+		handler := MakeLazyParsedReq(func(_ http.ResponseWriter, r *http.Request) {
+			assert.Same(t, existing, GetParams(r))
+		})
+		handler(httptest.NewRecorder(), req)
+	})
+}
+
+// TestMakeHTTPRouterLazyParsedReq tests the MakeHTTPRouterLazyParsedReq function
+func TestMakeHTTPRouterLazyParsedReq(t *testing.T) {
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPut, "/items/7", strings.NewReader(`{"item_id":1,"title":"Book"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	handler := MakeHTTPRouterLazyParsedReq(func(_ http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		params := GetParams(r)
+		assert.Equal(t, uint64(7), params.GetUint64("item_id"))
+		assert.Equal(t, "Book", params.GetString("title"))
+		assert.Equal(t, map[string]interface{}{
+			"item_id": uint64(7),
+			"title":   "Book",
+		}, params.Values)
+	})
+	handler(httptest.NewRecorder(), req, httprouter.Params{{Key: "item_id", Value: "7"}})
+}
+
+// TestParams_LazyConcurrentAccess tests that lazy params are parsed only once
+func TestParams_LazyConcurrentAccess(t *testing.T) {
+	body := &countingReader{Reader: strings.NewReader(`{"count":5}`)}
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/", body)
+	req.Header.Set("Content-Type", "application/json")
+	req.ContentLength = int64(len(`{"count":5}`))
+
+	params, _ := newLazyParams(req)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, 5, params.GetInt("count"))
+		}()
+	}
+	wg.Wait()
+
+	reads := body.reads
+	assert.Equal(t, 5, params.GetInt("count"))
+	assert.Equal(t, reads, body.reads)
+}
+
+// TestParams_IsParsed tests the IsParsed method on eager params
+func TestParams_IsParsed(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{}}
+	assert.True(t, params.IsParsed())
+}
@@ -0,0 +1,440 @@
+package parameters
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Names of the parse limits, used in LimitError
+const (
+	LimitBodySize     = "MaxBodySize"
+	LimitKeys         = "MaxKeys"
+	LimitDepth        = "MaxDepth"
+	LimitStringLength = "MaxStringLength"
+	LimitArrayLength  = "MaxArrayLength"
+)
+
+// ErrLimitExceeded is wrapped by every LimitError
+var ErrLimitExceeded = errors.New("parameters: parse limit exceeded")
+
+// errMalformedMsgpack is used internally when the msgpack scanner cannot read the data
+var errMalformedMsgpack = errors.New("malformed msgpack data")
+
+// errTrailingJSON is returned when the json body has data after the top level object
+var errTrailingJSON = errors.New("invalid character after top-level value")
+
+// errNotJSONObject is returned when the json body is not an object
+var errNotJSONObject = errors.New("json body is not an object")
+
+// Limits are the defensive limits enforced while decoding a request, a zero value means unlimited
+type Limits struct {
+	// MaxBodySize is the maximum size of the request body in bytes
+	MaxBodySize int64
+
+	// MaxKeys is the maximum number of keys in total (all nesting levels, query and body)
+	MaxKeys int
+
+	// MaxDepth is the maximum nesting depth of objects and arrays (a flat object is 1)
+	MaxDepth int
+
+	// MaxStringLength is the maximum length of a single string, []byte or key
+	MaxStringLength int
+
+	// MaxArrayLength is the maximum number of elements in a single array
+	MaxArrayLength int
+
+	// Uploads is the policy for multipart file uploads and attachments, an *UploadError is returned on violations
+	Uploads *UploadPolicy
+}
+
+// DefaultLimits are the limits used by ParseParams and the parsed request handlers
+var DefaultLimits Limits
+
+// LimitError is returned when a request exceeds one of the parse limits
+type LimitError struct {
+	// Limit is the name of the exceeded limit (LimitBodySize, LimitKeys, ...)
+	Limit string
+
+	// Max is the configured value of the exceeded limit
+	Max int64
+}
+
+// Error returns the error message
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("parameters: request exceeds %s of %d", e.Limit, e.Max)
+}
+
+// Unwrap returns ErrLimitExceeded
+func (e *LimitError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// structural returns true if any of the structural limits are set
+func (l Limits) structural() bool {
+	return l.MaxKeys > 0 || l.MaxDepth > 0 || l.MaxStringLength > 0 || l.MaxArrayLength > 0
+}
+
+// limitBody wraps the request body to enforce the MaxBodySize limit
+func (l Limits) limitBody(req *http.Request) {
+	if l.MaxBodySize > 0 && req.Body != nil {
+		req.Body = http.MaxBytesReader(nil, req.Body, l.MaxBodySize)
+	}
+}
+
+// bodyError converts a body read error into a LimitError if the body was too large
+func (l Limits) bodyError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return &LimitError{Limit: LimitBodySize, Max: maxBytesErr.Limit}
+	}
+	return nil
+}
+
+// limitCounter tracks the decoded keys against the limits
+type limitCounter struct {
+	limits Limits
+	keys   int
+}
+
+// addKeys counts the keys and returns an error if the limit is exceeded
+func (c *limitCounter) addKeys(n int) error {
+	c.keys += n
+	if c.limits.MaxKeys > 0 && c.keys > c.limits.MaxKeys {
+		return &LimitError{Limit: LimitKeys, Max: int64(c.limits.MaxKeys)}
+	}
+	return nil
+}
+
+// checkDepth returns an error if the depth exceeds the limit
+func (c *limitCounter) checkDepth(depth int) error {
+	if c.limits.MaxDepth > 0 && depth > c.limits.MaxDepth {
+		return &LimitError{Limit: LimitDepth, Max: int64(c.limits.MaxDepth)}
+	}
+	return nil
+}
+
+// checkString returns an error if the string length exceeds the limit
+func (c *limitCounter) checkString(length uint64) error {
+	if c.limits.MaxStringLength > 0 && length > uint64(c.limits.MaxStringLength) {
+		return &LimitError{Limit: LimitStringLength, Max: int64(c.limits.MaxStringLength)}
+	}
+	return nil
+}
+
+// checkArray returns an error if the array length exceeds the limit
+func (c *limitCounter) checkArray(length uint64) error {
+	if c.limits.MaxArrayLength > 0 && length > uint64(c.limits.MaxArrayLength) {
+		return &LimitError{Limit: LimitArrayLength, Max: int64(c.limits.MaxArrayLength)}
+	}
+	return nil
+}
+
+// checkForm enforces the limits on the parsed form values, the length of the
+// typed values (decoded later) is not checked
+func (c *limitCounter) checkForm(form map[string][]string, typed map[string]string) error {
+	if err := c.addKeys(len(form)); err != nil {
+		return err
+	}
+	for k, v := range form {
+		if err := c.checkString(uint64(len(k))); err != nil {
+			return err
+		}
+		if err := c.checkArray(uint64(len(v))); err != nil {
+			return err
+		}
+		if _, isTyped := typed[k]; isTyped {
+			continue
+		}
+		for _, s := range v {
+			if err := c.checkString(uint64(len(s))); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// decodeJSON decodes a json object token by token, enforcing the limits while decoding
+func (c *limitCounter) decodeJSON(r io.Reader) (map[string]interface{}, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, errNotJSONObject
+	}
+	values, err := c.jsonObject(dec, 1)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errTrailingJSON
+	}
+	return values, nil
+}
+
+// decodeJSONValue decodes any json value, enforcing the limits while decoding
+func (c *limitCounter) decodeJSONValue(r io.Reader) (interface{}, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	value, err := c.jsonValue(dec, 0)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errTrailingJSON
+	}
+	return value, nil
+}
+
+// jsonValue decodes the next json value
+func (c *limitCounter) jsonValue(dec *json.Decoder, depth int) (interface{}, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch v := tok.(type) {
+	case json.Delim:
+		if v == '{' {
+			return c.jsonObject(dec, depth+1)
+		}
+		return c.jsonArray(dec, depth+1)
+	case string:
+		if err = c.checkString(uint64(len(v))); err != nil {
+			return nil, err
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
+
+// jsonObject decodes a json object after the opening delimiter
+func (c *limitCounter) jsonObject(dec *json.Decoder, depth int) (map[string]interface{}, error) {
+	if err := c.checkDepth(depth); err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{})
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		if err = c.addKeys(1); err != nil {
+			return nil, err
+		}
+		if err = c.checkString(uint64(len(key))); err != nil {
+			return nil, err
+		}
+		if values[key], err = c.jsonValue(dec, depth); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// jsonArray decodes a json array after the opening delimiter
+func (c *limitCounter) jsonArray(dec *json.Decoder, depth int) ([]interface{}, error) {
+	if err := c.checkDepth(depth); err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, 0)
+	for dec.More() {
+		if err := c.checkArray(uint64(len(values) + 1)); err != nil {
+			return nil, err
+		}
+		val, err := c.jsonValue(dec, depth)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, val)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// scanMsgpack walks the msgpack data without decoding it, enforcing the limits.
+// Top level arrays are treated as a list of key/value pairs. Malformed data is
+// left to the msgpack decoder to report.
+func (c *limitCounter) scanMsgpack(data []byte) error {
+	s := &msgpackScanner{counter: c, data: data}
+	for s.pos < len(s.data) {
+		if err := s.value(0); err != nil {
+			if errors.Is(err, errMalformedMsgpack) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// msgpackScanner reads the msgpack headers to find the sizes of all values
+type msgpackScanner struct {
+	counter *limitCounter
+	data    []byte
+	pos     int
+}
+
+// readUint reads a big endian unsigned integer of the given size
+func (s *msgpackScanner) readUint(size int) (uint64, error) {
+	if len(s.data)-s.pos < size {
+		return 0, errMalformedMsgpack
+	}
+	b := s.data[s.pos : s.pos+size]
+	s.pos += size
+	switch size {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	default:
+		return binary.BigEndian.Uint64(b), nil
+	}
+}
+
+// skip skips n bytes
+func (s *msgpackScanner) skip(n uint64) error {
+	if uint64(len(s.data)-s.pos) < n {
+		return errMalformedMsgpack
+	}
+	s.pos += int(n)
+	return nil
+}
+
+// str checks and skips a string or binary value of the given length
+func (s *msgpackScanner) str(n uint64) error {
+	if err := s.counter.checkString(n); err != nil {
+		return err
+	}
+	return s.skip(n)
+}
+
+// sized reads a length header of the given size and calls fn with it
+func (s *msgpackScanner) sized(size int, fn func(n uint64) error) error {
+	n, err := s.readUint(size)
+	if err != nil {
+		return err
+	}
+	return fn(n)
+}
+
+// value scans the next value
+func (s *msgpackScanner) value(depth int) error {
+	if s.pos >= len(s.data) {
+		return errMalformedMsgpack
+	}
+	b := s.data[s.pos]
+	s.pos++
+	switch {
+	case b <= 0x7f, b >= 0xe0, b == 0xc0, b == 0xc2, b == 0xc3:
+		return nil
+	case b <= 0x8f:
+		return s.mapBody(uint64(b&0x0f), depth)
+	case b <= 0x9f:
+		return s.arrayBody(uint64(b&0x0f), depth)
+	case b <= 0xbf:
+		return s.str(uint64(b & 0x1f))
+	}
+
+	mapBody := func(n uint64) error { return s.mapBody(n, depth) }
+	arrayBody := func(n uint64) error { return s.arrayBody(n, depth) }
+	switch b {
+	case 0xc4, 0xd9:
+		return s.sized(1, s.str)
+	case 0xc5, 0xda:
+		return s.sized(2, s.str)
+	case 0xc6, 0xdb:
+		return s.sized(4, s.str)
+	case 0xc7:
+		return s.sized(1, func(n uint64) error { return s.skip(n + 1) })
+	case 0xc8:
+		return s.sized(2, func(n uint64) error { return s.skip(n + 1) })
+	case 0xc9:
+		return s.sized(4, func(n uint64) error { return s.skip(n + 1) })
+	case 0xcc, 0xd0:
+		return s.skip(1)
+	case 0xcd, 0xd1, 0xd4:
+		return s.skip(2)
+	case 0xd5:
+		return s.skip(3)
+	case 0xca, 0xce, 0xd2:
+		return s.skip(4)
+	case 0xd6:
+		return s.skip(5)
+	case 0xcb, 0xcf, 0xd3:
+		return s.skip(8)
+	case 0xd7:
+		return s.skip(9)
+	case 0xd8:
+		return s.skip(17)
+	case 0xdc:
+		return s.sized(2, arrayBody)
+	case 0xdd:
+		return s.sized(4, arrayBody)
+	case 0xde:
+		return s.sized(2, mapBody)
+	case 0xdf:
+		return s.sized(4, mapBody)
+	default:
+		return errMalformedMsgpack
+	}
+}
+
+// mapBody scans the n entries of a map
+func (s *msgpackScanner) mapBody(n uint64, depth int) error {
+	depth++
+	if err := s.counter.checkDepth(depth); err != nil {
+		return err
+	}
+	if err := s.counter.addKeys(int(n)); err != nil {
+		return err
+	}
+	if n > uint64(len(s.data)) {
+		return errMalformedMsgpack
+	}
+	for i := uint64(0); i < 2*n; i++ {
+		if err := s.value(depth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// arrayBody scans the n elements of an array, top level arrays hold key/value pairs
+func (s *msgpackScanner) arrayBody(n uint64, depth int) error {
+	depth++
+	if err := s.counter.checkDepth(depth); err != nil {
+		return err
+	}
+	if depth == 1 {
+		if err := s.counter.addKeys(int(n / 2)); err != nil {
+			return err
+		}
+	} else if err := s.counter.checkArray(n); err != nil {
+		return err
+	}
+	if n > uint64(len(s.data)) {
+		return errMalformedMsgpack
+	}
+	for i := uint64(0); i < n; i++ {
+		if err := s.value(depth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+package parameters
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+)
+
+// newLimitsRequest creates a request with the body and content type
+func newLimitsRequest(t *testing.T, target, contentType string, body []byte) *http.Request {
+	t.Helper()
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, target, bytes.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return req
+}
+
+// encodeMsgpack encodes the value as msgpack
+func encodeMsgpack(t *testing.T, v interface{}) []byte {
+	t.Helper()
+	var mh codec.MsgpackHandle
+	mh.WriteExt = true
+	var buf bytes.Buffer
+	require.NoError(t, codec.NewEncoder(&buf, &mh).Encode(v))
+	return buf.Bytes()
+}
+
+// TestParseParamsWithLimits tests the ParseParamsWithLimits function
+func TestParseParamsWithLimits(t *testing.T) {
+	nested := map[string]interface{}{
+		"a": map[string]interface{}{
+			"b": map[string]interface{}{"c": 1},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		target        string
+		contentType   string
+		body          []byte
+		limits        Limits
+		expectedLimit string
+	}{
+		{
+			name:          "json too many keys",
+			contentType:   "application/json",
+			body:          []byte(`{"a":1,"b":2,"c":{"d":3}}`),
+			limits:        Limits{MaxKeys: 3},
+			expectedLimit: LimitKeys,
+		},
+		{
+			name:          "json query and body keys are counted together",
+			target:        "/?x=1&y=2",
+			contentType:   "application/json",
+			body:          []byte(`{"a":1,"b":2}`),
+			limits:        Limits{MaxKeys: 3},
+			expectedLimit: LimitKeys,
+		},
+		{
+			name:          "json too deep",
+			contentType:   "application/json",
+			body:          []byte(`{"a":{"b":{"c":[1]}}}`),
+			limits:        Limits{MaxDepth: 3},
+			expectedLimit: LimitDepth,
+		},
+		{
+			name:          "json string too long",
+			contentType:   "application/json",
+			body:          []byte(`{"a":"abcdefghijk"}`),
+			limits:        Limits{MaxStringLength: 10},
+			expectedLimit: LimitStringLength,
+		},
+		{
+			name:          "json key too long",
+			contentType:   "application/json",
+			body:          []byte(`{"abcdefghijk":1}`),
+			limits:        Limits{MaxStringLength: 10},
+			expectedLimit: LimitStringLength,
+		},
+		{
+			name:          "json array too long",
+			contentType:   "application/json",
+			body:          []byte(`{"a":[1,2,3,4]}`),
+			limits:        Limits{MaxArrayLength: 3},
+			expectedLimit: LimitArrayLength,
+		},
+		{
+			name:          "json body too large",
+			contentType:   "application/json",
+			body:          []byte(`{"a":"` + strings.Repeat("x", 100) + `"}`),
+			limits:        Limits{MaxBodySize: 50},
+			expectedLimit: LimitBodySize,
+		},
+		{
+			name:          "form body too large",
+			contentType:   "application/x-www-form-urlencoded",
+			body:          []byte("a=" + strings.Repeat("x", 100)),
+			limits:        Limits{MaxBodySize: 50},
+			expectedLimit: LimitBodySize,
+		},
+		{
+			name:          "form value too long",
+			contentType:   "application/x-www-form-urlencoded",
+			body:          []byte("a=" + strings.Repeat("x", 11)),
+			limits:        Limits{MaxStringLength: 10},
+			expectedLimit: LimitStringLength,
+		},
+		{
+			name:          "query too many keys",
+			target:        "/?a=1&b=2&c=3",
+			limits:        Limits{MaxKeys: 2},
+			expectedLimit: LimitKeys,
+		},
+		{
+			name:          "msgpack map too many keys",
+			contentType:   "application/x-msgpack",
+			body:          encodeMsgpack(t, map[string]interface{}{"a": 1, "b": 2, "c": 3}),
+			limits:        Limits{MaxKeys: 2},
+			expectedLimit: LimitKeys,
+		},
+		{
+			name:          "msgpack key value list too many keys",
+			contentType:   "application/x-msgpack",
+			body:          encodeMsgpack(t, []interface{}{"a", 1, "b", 2, "c", 3}),
+			limits:        Limits{MaxKeys: 2},
+			expectedLimit: LimitKeys,
+		},
+		{
+			name:          "msgpack too deep",
+			contentType:   "application/x-msgpack",
+			body:          encodeMsgpack(t, nested),
+			limits:        Limits{MaxDepth: 2},
+			expectedLimit: LimitDepth,
+		},
+		{
+			name:          "msgpack string too long",
+			contentType:   "application/x-msgpack",
+			body:          encodeMsgpack(t, map[string]interface{}{"a": strings.Repeat("x", 300)}),
+			limits:        Limits{MaxStringLength: 255},
+			expectedLimit: LimitStringLength,
+		},
+		{
+			name:          "msgpack bytes too long",
+			contentType:   "application/x-msgpack",
+			body:          encodeMsgpack(t, map[string]interface{}{"a": bytes.Repeat([]byte{1}, 20)}),
+			limits:        Limits{MaxStringLength: 10},
+			expectedLimit: LimitStringLength,
+		},
+		{
+			name:          "msgpack array too long",
+			contentType:   "application/x-msgpack",
+			body:          encodeMsgpack(t, map[string]interface{}{"a": make([]int, 20)}),
+			limits:        Limits{MaxArrayLength: 16},
+			expectedLimit: LimitArrayLength,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := tt.target
+			if target == "" {
+				target = "/"
+			}
+			req := newLimitsRequest(t, target, tt.contentType, tt.body)
+			params, err := ParseParamsWithLimits(req, tt.limits)
+			require.Error(t, err)
+			require.ErrorIs(t, err, ErrLimitExceeded)
+
+			var limitErr *LimitError
+			require.ErrorAs(t, err, &limitErr)
+			assert.Equal(t, tt.expectedLimit, limitErr.Limit)
+			assert.Contains(t, err.Error(), tt.expectedLimit)
+
+			require.NotNil(t, params)
+			assert.Empty(t, params.Values)
+			assert.Equal(t, err, params.ParseError())
+		})
+	}
+}
+
+// TestParseParamsWithLimits_WithinLimits tests requests that are within the limits
+func TestParseParamsWithLimits_WithinLimits(t *testing.T) {
+	limits := Limits{
+		MaxBodySize:     1024,
+		MaxKeys:         10,
+		MaxDepth:        3,
+		MaxStringLength: 20,
+		MaxArrayLength:  5,
+	}
+
+	t.Run("json", func(t *testing.T) {
+		body := []byte(`{"name":"Alice","tags":["a","b"],"address":{"zip":"12345","geo":[1.5,2.5]},"active":true,"none":null}`)
+		req := newLimitsRequest(t, "/?page=2", "application/json", body)
+		params, err := ParseParamsWithLimits(req, limits)
+		require.NoError(t, err)
+		require.NoError(t, params.ParseError())
+		assert.Equal(t, "Alice", params.GetString(testNameParam))
+		assert.Equal(t, []string{"a", "b"}, params.GetStringSlice("tags"))
+		assert.Equal(t, "12345", params.GetString("address.zip"))
+		assert.InDelta(t, 2.5, params.GetFloatSlice("address.geo")[1], 0.001)
+		assert.True(t, params.GetBool("active"))
+		assert.Equal(t, 2, params.GetInt("page"))
+	})
+
+	t.Run("invalid json falls back to the query values", func(t *testing.T) {
+		req := newLimitsRequest(t, "/?page=2", "application/json", []byte(`{"name":`))
+		params, err := ParseParamsWithLimits(req, limits)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"page": "2"}, params.Values)
+	})
+
+	t.Run("msgpack", func(t *testing.T) {
+		body := encodeMsgpack(t, map[string]interface{}{"name": "Bob", "ids": []int{1, 2, 3}})
+		req := newLimitsRequest(t, "/", "application/x-msgpack", body)
+		params, err := ParseParamsWithLimits(req, limits)
+		require.NoError(t, err)
+		assert.Equal(t, "Bob", params.GetString(testNameParam))
+		ids, ok := params.Get("ids")
+		assert.True(t, ok)
+		assert.Len(t, ids, 3)
+	})
+}
+
+// TestParseParams_DefaultLimits tests that ParseParams enforces the DefaultLimits
+func TestParseParams_DefaultLimits(t *testing.T) {
+	previous := DefaultLimits
+	defer func() { DefaultLimits = previous }()
+	DefaultLimits = Limits{MaxKeys: 1}
+
+	req := newLimitsRequest(t, "/", "application/json", []byte(`{"a":1,"b":2}`))
+	params := ParseParams(req)
+	require.NotNil(t, params)
+
+	var limitErr *LimitError
+	require.ErrorAs(t, params.ParseError(), &limitErr)
+	assert.Equal(t, LimitKeys, limitErr.Limit)
+	assert.Equal(t, int64(1), limitErr.Max)
+}
+
+// TestLimitCounter_ScanMsgpackMalformed tests that malformed msgpack is left to the decoder
+func TestLimitCounter_ScanMsgpackMalformed(t *testing.T) {
+	counter := &limitCounter{limits: Limits{MaxKeys: 1}}
+	require.NoError(t, counter.scanMsgpack([]byte{0xdc, 0x00}))
+	require.NoError(t, counter.scanMsgpack([]byte{0xc1}))
+	require.NoError(t, counter.scanMsgpack([]byte{0xa5, 'a'}))
+}
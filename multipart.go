@@ -0,0 +1,152 @@
+package parameters
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/ugorji/go/codec"
+)
+
+// multipartMaxMemory is the memory used for a multipart body, the rest is stored in temporary files
+const multipartMaxMemory = 10000000
+
+// Media types of the parts that are decoded into nested values
+const (
+	mediaTypeJSON    = "application/json"
+	mediaTypeMsgpack = "application/x-msgpack"
+)
+
+// partScanner inspects the parts of a multipart/form-data body while the form parser reads it,
+// enforcing the upload policy (if any) and collecting the media types of the non-file parts that
+// can be decoded (json, msgpack). The body is not buffered, the scanner only sees what the parser reads.
+type partScanner struct {
+	policy    *UploadPolicy
+	writer    *io.PipeWriter
+	done      chan struct{}
+	partTypes map[string]string
+	err       error
+}
+
+// scannedBody is the request body read by the form parser, everything read is passed to the scanner
+type scannedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// scanMultipart starts scanning the parts of the request body as it is read, returns nil if the
+// content type has no boundary
+func scanMultipart(req *http.Request, policy *UploadPolicy) *partScanner {
+	_, ctParams, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil || ctParams["boundary"] == "" || req.Body == nil {
+		return nil // the form parser reports invalid content types
+	}
+
+	reader, writer := io.Pipe()
+	s := &partScanner{
+		policy:    policy,
+		writer:    writer,
+		done:      make(chan struct{}),
+		partTypes: make(map[string]string),
+	}
+	req.Body = &scannedBody{Reader: io.TeeReader(req.Body, writer), Closer: req.Body}
+	go s.scan(multipart.NewReader(reader, ctParams["boundary"]), reader)
+	return s
+}
+
+// scan reads the parts, a policy violation stops the form parser with the error
+func (s *partScanner) scan(reader *multipart.Reader, pipe *io.PipeReader) {
+	defer close(s.done)
+	if err := s.scanParts(reader); err != nil {
+		var uploadErr *UploadError
+		if errors.As(err, &uploadErr) {
+			s.err = err
+		}
+		_ = pipe.CloseWithError(err)
+		return
+	}
+
+	// Keep reading so the form parser is never blocked
+	_, _ = io.Copy(io.Discard, pipe)
+}
+
+// scanParts checks every part until the end of the body
+func (s *partScanner) scanParts(reader *multipart.Reader) error {
+	perField := make(map[string]int)
+	total := 0
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return nil //nolint:nilerr // the end of the body, malformed parts are reported by the form parser
+		}
+
+		field := part.FormName()
+		if part.FileName() == "" {
+			if _, exists := s.partTypes[field]; !exists {
+				if mediaType := decodableMediaType(part.Header.Get("Content-Type")); mediaType != "" {
+					s.partTypes[field] = mediaType
+				}
+			}
+			continue
+		}
+
+		total++
+		perField[field]++
+		if s.policy != nil {
+			if err = s.policy.checkPart(part, total, perField[field]); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// wait stops the scanner once the form is parsed, returns the part types or the policy violation
+func (s *partScanner) wait() (map[string]string, error) {
+	_ = s.writer.Close()
+	<-s.done
+	return s.partTypes, s.err
+}
+
+// decodableMediaType returns the media type if the content type is json or msgpack
+func decodableMediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	switch {
+	case mediaType == mediaTypeJSON, strings.HasSuffix(mediaType, "+json"):
+		return mediaTypeJSON
+	case mediaType == mediaTypeMsgpack, mediaType == "application/msgpack", mediaType == "application/vnd.msgpack":
+		return mediaTypeMsgpack
+	}
+	return ""
+}
+
+// decodePart decodes the value of a part by its media type, enforcing the limits
+func (c *limitCounter) decodePart(value, mediaType string) (interface{}, error) {
+	switch mediaType {
+	case mediaTypeJSON:
+		if c.limits.structural() {
+			return c.decodeJSONValue(strings.NewReader(value))
+		}
+		var decoded interface{}
+		err := unmarshalJSON([]byte(value), &decoded)
+		return decoded, err
+	case mediaTypeMsgpack:
+		if c.limits.structural() {
+			if err := c.scanMsgpack([]byte(value)); err != nil {
+				return nil, err
+			}
+		}
+		var mh codec.MsgpackHandle
+		mh.MapType = reflect.TypeOf(map[string]interface{}{})
+		var decoded interface{}
+		err := codec.NewDecoderBytes([]byte(value), &mh).Decode(&decoded)
+		return decoded, err
+	}
+	return value, nil
+}
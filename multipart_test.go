@@ -0,0 +1,118 @@
+package parameters
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPart is a typed part to add to a multipart request
+type testPart struct {
+	field       string
+	contentType string
+	content     []byte
+}
+
+// newTypedMultipartRequest creates a multipart/form-data request with typed parts and a file
+func newTypedMultipartRequest(t *testing.T, parts ...testPart) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, p := range parts {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", `form-data; name="`+p.field+`"`)
+		if p.contentType != "" {
+			header.Set("Content-Type", p.contentType)
+		}
+		part, err := writer.CreatePart(header)
+		require.NoError(t, err)
+		_, err = part.Write(p.content)
+		require.NoError(t, err)
+	}
+	file, err := writer.CreateFormFile("photo", "photo.png")
+	require.NoError(t, err)
+	_, err = file.Write(testPNG)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/upload", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+// TestParseParams_MultipartTypedParts tests decoding json and msgpack parts of a multipart form
+func TestParseParams_MultipartTypedParts(t *testing.T) {
+	req := newTypedMultipartRequest(t,
+		testPart{field: "metadata", contentType: "application/json; charset=utf-8", content: []byte(`{"title":"Holiday","tags":["beach","sun"],"rating":4}`)},
+		testPart{field: "settings", contentType: "application/x-msgpack", content: encodeMsgpack(t, map[string]interface{}{"private": true})},
+		testPart{field: "geo", contentType: "application/geo+json", content: []byte(`{"type":"Point"}`)},
+		testPart{field: "list", contentType: "application/json", content: []byte(`[1,2,3]`)},
+		testPart{field: "broken", contentType: "application/json", content: []byte(`{"title":`)},
+		testPart{field: "note", contentType: "text/plain", content: []byte(`{"not":"decoded"}`)},
+		testPart{field: testNameParam, content: []byte("Alice")},
+	)
+	params := ParseParams(req)
+	require.NoError(t, params.ParseError())
+
+	assert.Equal(t, "Holiday", params.GetString("metadata.title"))
+	assert.Equal(t, []string{"beach", "sun"}, params.GetStringSlice("metadata.tags"))
+	assert.Equal(t, 4, params.GetInt("metadata.rating"))
+	assert.True(t, params.GetBool("settings.private"))
+	assert.Equal(t, "Point", params.GetString("geo.type"))
+	assert.Equal(t, []int{1, 2, 3}, params.GetIntSlice("list"))
+	assert.Equal(t, `{"title":`, params.GetString("broken"))
+	assert.Equal(t, `{"not":"decoded"}`, params.GetString("note"))
+	assert.Equal(t, "Alice", params.GetString(testNameParam))
+
+	fh, ok := params.GetFileOk("photo")
+	require.True(t, ok)
+	assert.Equal(t, "photo.png", fh.Filename)
+}
+
+// TestParseParamsWithLimits_MultipartTypedParts tests that the limits apply to the decoded parts
+func TestParseParamsWithLimits_MultipartTypedParts(t *testing.T) {
+	req := newTypedMultipartRequest(t,
+		testPart{field: "metadata", contentType: "application/json", content: []byte(`{"a":{"b":{"c":1}}}`)},
+	)
+	_, err := ParseParamsWithLimits(req, Limits{MaxDepth: 2})
+
+	var limitErr *LimitError
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, LimitDepth, limitErr.Limit)
+
+	// The length of the raw json part is not checked as a string
+	req = newTypedMultipartRequest(t,
+		testPart{field: "metadata", contentType: "application/json", content: []byte(`{"title":"Holiday","rating":4}`)},
+	)
+	params, err := ParseParamsWithLimits(req, Limits{MaxStringLength: 10})
+	require.NoError(t, err)
+	assert.Equal(t, "Holiday", params.GetString("metadata.title"))
+}
+
+// TestParseParamsWithLimits_MultipartTempFiles tests that the temporary files are removed when the form is rejected
+func TestParseParamsWithLimits_MultipartTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TMPDIR", dir)
+
+	// The file is larger than the memory used for the form, so it is stored in a temporary file
+	req := newMultipartRequest(t, map[string]string{"a": "1", "b": "2", "c": "3"},
+		testFile{field: "doc", filename: "doc.txt", content: bytes.Repeat([]byte("x"), multipartMaxMemory+1)},
+	)
+	_, err := ParseParamsWithLimits(req, Limits{MaxKeys: 2})
+
+	var limitErr *LimitError
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, LimitKeys, limitErr.Limit)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
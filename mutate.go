@@ -0,0 +1,215 @@
+package parameters
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// Errors returned when changing the params
+var (
+	// ErrPathConflict is returned when a value along the path is neither an object nor an array
+	ErrPathConflict = errors.New("parameters: path conflicts with an existing value")
+
+	// ErrMergeConflict is returned by Merge with MergeError when both params have different values for a path
+	ErrMergeConflict = errors.New("parameters: conflicting values")
+)
+
+// MergeStrategy resolves the conflicts of Merge, when both params have a value for the same path
+// and the values are not both objects (objects are always merged key by key)
+type MergeStrategy int
+
+// The merge strategies
+const (
+	// MergeOverwrite uses the values of the other params
+	MergeOverwrite MergeStrategy = iota
+
+	// MergeKeep keeps the values of these params
+	MergeKeep
+
+	// MergeError returns an ErrMergeConflict without changing the params
+	MergeError
+)
+
+// setIn sets the value at the keys below val, creating objects for missing keys, returns the updated val
+func setIn(val interface{}, keys []string, value interface{}) (interface{}, error) {
+	if len(keys) == 0 {
+		return value, nil
+	}
+	key := keys[0]
+	switch v := val.(type) {
+	case nil:
+		return setIn(map[string]interface{}{}, keys, value)
+	case map[string]interface{}:
+		updated, err := setIn(v[key], keys[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		v[key] = updated
+		return v, nil
+	case map[interface{}]interface{}:
+		updated, err := setIn(v[key], keys[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		v[key] = updated
+		return v, nil
+	case []interface{}:
+		// An index of the length appends to the array
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i > len(v) {
+			return nil, ErrPathConflict
+		}
+		if i == len(v) {
+			v = append(v, nil)
+		}
+		updated, err := setIn(v[i], keys[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		v[i] = updated
+		return v, nil
+	default:
+		return nil, ErrPathConflict
+	}
+}
+
+// Set sets the value at the path (e.g. "billing.zip" or "items[0].sku"), creating the missing objects
+// along the path, returns an ErrPathConflict if a value along the path is not an object or an array
+func (p *Params) Set(path string, value interface{}) error {
+	p.ensureParsed()
+	if p.Values == nil {
+		p.Values = make(map[string]interface{})
+	}
+	if _, ok := p.Values[path]; ok {
+		p.Values[path] = value
+		return nil
+	}
+	if _, err := setIn(p.Values, parsePath(path), value); err != nil {
+		return fmt.Errorf("%w: %s", err, path)
+	}
+	return nil
+}
+
+// deleteIn removes the value at the keys below val, returns the updated val and true if it was found
+func deleteIn(val interface{}, keys []string) (interface{}, bool) {
+	key, last := keys[0], len(keys) == 1
+	switch v := val.(type) {
+	case map[string]interface{}:
+		child, ok := v[key]
+		if ok && last {
+			delete(v, key)
+		} else if ok {
+			v[key], ok = deleteIn(child, keys[1:])
+		}
+		return v, ok
+	case map[interface{}]interface{}:
+		child, ok := v[key]
+		if ok && last {
+			delete(v, key)
+		} else if ok {
+			v[key], ok = deleteIn(child, keys[1:])
+		}
+		return v, ok
+	case []interface{}:
+		i, err := strconv.Atoi(key)
+		if err != nil || i < 0 || i >= len(v) {
+			return v, false
+		}
+		if last {
+			return append(v[:i:i], v[i+1:]...), true
+		}
+		updated, ok := deleteIn(v[i], keys[1:])
+		if ok {
+			v[i] = updated
+		}
+		return v, ok
+	default:
+		return val, false
+	}
+}
+
+// Delete removes the value at the path (elements of arrays are removed), returns true if it was found
+func (p *Params) Delete(path string) bool {
+	p.ensureParsed()
+	if _, ok := p.Values[path]; ok {
+		delete(p.Values, path)
+		return true
+	}
+	_, ok := deleteIn(p.Values, parsePath(path))
+	return ok
+}
+
+// mergeConflict returns an ErrMergeConflict for the first path with different values in both objects
+func mergeConflict(dst, src map[string]interface{}, path string) error {
+	for k, srcVal := range src {
+		dstVal, ok := dst[k]
+		if !ok {
+			continue
+		}
+		keyPath := EscapeKey(k)
+		if path != "" {
+			keyPath = path + "." + keyPath
+		}
+		dstObj, dstIsObject := asObject(dstVal)
+		srcObj, srcIsObject := asObject(srcVal)
+		if dstIsObject && srcIsObject {
+			if err := mergeConflict(dstObj, srcObj, keyPath); err != nil {
+				return err
+			}
+		} else if !reflect.DeepEqual(dstVal, srcVal) {
+			return fmt.Errorf("%w: %s", ErrMergeConflict, keyPath)
+		}
+	}
+	return nil
+}
+
+// mergeInto merges the src object into the dst object, the objects and arrays of src are copied so
+// later changes of dst do not change src
+func mergeInto(dst, src map[string]interface{}, strategy MergeStrategy) {
+	for k, srcVal := range src {
+		dstVal, exists := dst[k]
+		srcObj, srcIsObject := asObject(srcVal)
+		if dstObj, dstIsObject := asObject(dstVal); exists && dstIsObject && srcIsObject {
+			mergeInto(dstObj, srcObj, strategy)
+			if _, isStringMap := dstVal.(map[string]interface{}); !isStringMap {
+				dst[k] = dstObj
+			}
+			continue
+		}
+		if exists && strategy == MergeKeep {
+			continue
+		}
+		if srcIsObject {
+			obj := make(map[string]interface{}, len(srcObj))
+			mergeInto(obj, srcObj, strategy)
+			srcVal = obj
+		} else {
+			srcVal = deepCopy(srcVal)
+		}
+		dst[k] = srcVal
+	}
+}
+
+// Merge deep merges the values of the other params into these params: objects are merged key by key
+// and other values (including arrays) conflict, resolved by the strategy
+//
+//	err := params.Merge(defaults, parameters.MergeKeep)
+func (p *Params) Merge(other *Params, strategy MergeStrategy) error {
+	if other == nil {
+		return nil
+	}
+	p.ensureParsed()
+	other.ensureParsed()
+	if p.Values == nil {
+		p.Values = make(map[string]interface{})
+	}
+	if strategy == MergeError {
+		if err := mergeConflict(p.Values, other.Values, ""); err != nil {
+			return err
+		}
+	}
+	mergeInto(p.Values, other.Values, strategy)
+	return nil
+}
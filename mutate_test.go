@@ -0,0 +1,165 @@
+package parameters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParams_Set tests setting values by path
+func TestParams_Set(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"name":         "John",
+		"items":        []interface{}{map[string]interface{}{"sku": "A-1"}},
+		"meta":         map[interface{}]interface{}{"source": "msgpack"},
+		"billing[zip]": "10115",
+	}}
+
+	require.NoError(t, params.Set("billing.address.city", "Berlin"))
+	assert.Equal(t, "Berlin", params.GetString("billing.address.city"))
+
+	require.NoError(t, params.Set("items[0].sku", "B-2"))
+	assert.Equal(t, "B-2", params.GetString("items.0.sku"))
+
+	require.NoError(t, params.Set("items.1", map[string]interface{}{"sku": "C-3"}))
+	assert.Equal(t, []string{"B-2", "C-3"}, params.GetAllStrings("items.*.sku"))
+
+	require.NoError(t, params.Set("meta.version", 2))
+	assert.Equal(t, 2, params.GetInt("meta.version"))
+	assert.Equal(t, "msgpack", params.GetString("meta.source"))
+
+	require.NoError(t, params.Set(`config.v1\.2`, true))
+	assert.True(t, params.GetBool(`config.v1\.2`))
+
+	require.NoError(t, params.Set("billing[zip]", "10117"))
+	assert.Equal(t, "10117", params.Values["billing[zip]"])
+
+	err := params.Set("name.first", "John")
+	require.ErrorIs(t, err, ErrPathConflict)
+	assert.Equal(t, "John", params.GetString("name"))
+
+	err = params.Set("items.5.sku", "D-4")
+	require.ErrorIs(t, err, ErrPathConflict)
+
+	empty := &Params{}
+	require.NoError(t, empty.Set("a.b", 1))
+	assert.Equal(t, 1, empty.GetInt("a.b"))
+}
+
+// TestParams_Delete tests removing values by path
+func TestParams_Delete(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"name": "John",
+		"billing": map[string]interface{}{
+			"zip":  "10115",
+			"city": "Berlin",
+		},
+		"items": []interface{}{
+			map[string]interface{}{"sku": "A-1", "price": 10},
+			map[string]interface{}{"sku": "B-2"},
+		},
+		"meta": map[interface{}]interface{}{"source": "msgpack"},
+	}}
+
+	assert.True(t, params.Delete("billing.zip"))
+	_, ok := params.Get("billing.zip")
+	assert.False(t, ok)
+	assert.Equal(t, "Berlin", params.GetString("billing.city"))
+
+	assert.True(t, params.Delete("items[0].price"))
+	_, ok = params.Get("items.0.price")
+	assert.False(t, ok)
+
+	assert.True(t, params.Delete("items.0"))
+	assert.Equal(t, []string{"B-2"}, params.GetAllStrings("items.*.sku"))
+
+	assert.True(t, params.Delete("meta.source"))
+	assert.True(t, params.Delete("name"))
+
+	assert.False(t, params.Delete("name"))
+	assert.False(t, params.Delete("billing.zip"))
+	assert.False(t, params.Delete("billing.city.x"))
+	assert.False(t, params.Delete("items.3"))
+	assert.False(t, params.Delete("missing.key"))
+}
+
+// TestParams_Merge tests deep merging with the strategies
+func TestParams_Merge(t *testing.T) {
+	newParams := func() *Params {
+		return &Params{Values: map[string]interface{}{
+			"name":    "John",
+			"billing": map[string]interface{}{"zip": "10115", "city": "Berlin"},
+			"tags":    []interface{}{"a"},
+		}}
+	}
+	other := &Params{Values: map[string]interface{}{
+		"name":     "Jane",
+		"billing":  map[string]interface{}{"zip": "10117", "country": "DE"},
+		"tags":     []interface{}{"b"},
+		"shipping": map[string]interface{}{"method": "express"},
+	}}
+
+	t.Run("overwrite", func(t *testing.T) {
+		params := newParams()
+		require.NoError(t, params.Merge(other, MergeOverwrite))
+		assert.Equal(t, "Jane", params.GetString("name"))
+		assert.Equal(t, "10117", params.GetString("billing.zip"))
+		assert.Equal(t, "Berlin", params.GetString("billing.city"))
+		assert.Equal(t, "DE", params.GetString("billing.country"))
+		assert.Equal(t, []string{"b"}, params.GetStringSlice("tags"))
+		assert.Equal(t, "express", params.GetString("shipping.method"))
+	})
+
+	t.Run("keep", func(t *testing.T) {
+		params := newParams()
+		require.NoError(t, params.Merge(other, MergeKeep))
+		assert.Equal(t, "John", params.GetString("name"))
+		assert.Equal(t, "10115", params.GetString("billing.zip"))
+		assert.Equal(t, "DE", params.GetString("billing.country"))
+		assert.Equal(t, []string{"a"}, params.GetStringSlice("tags"))
+		assert.Equal(t, "express", params.GetString("shipping.method"))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		params := newParams()
+		err := params.Merge(other, MergeError)
+		require.ErrorIs(t, err, ErrMergeConflict)
+		assert.Equal(t, newParams().Values, params.Values)
+
+		compatible := &Params{Values: map[string]interface{}{
+			"name":    "John",
+			"billing": map[string]interface{}{"country": "DE"},
+		}}
+		require.NoError(t, params.Merge(compatible, MergeError))
+		assert.Equal(t, "DE", params.GetString("billing.country"))
+	})
+
+	t.Run("copies objects", func(t *testing.T) {
+		params := newParams()
+		require.NoError(t, params.Merge(other, MergeOverwrite))
+		require.NoError(t, params.Set("shipping.method", "standard"))
+		assert.Equal(t, "express", other.GetString("shipping.method"))
+	})
+
+	t.Run("copies arrays", func(t *testing.T) {
+		src := &Params{Values: map[string]interface{}{
+			"items": []interface{}{map[string]interface{}{"sku": "A-1"}},
+			"data":  []byte("abc"),
+		}}
+		params := newParams()
+		require.NoError(t, params.Merge(src, MergeOverwrite))
+		require.NoError(t, params.Set("items.0.sku", "B-2"))
+		params.GetBytes("data")[0] = 'x'
+
+		assert.Equal(t, "B-2", params.GetString("items.0.sku"))
+		assert.Equal(t, "A-1", src.GetString("items.0.sku"))
+		assert.Equal(t, []byte("abc"), src.Values["data"])
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		params := newParams()
+		require.NoError(t, params.Merge(nil, MergeError))
+		assert.Equal(t, newParams().Values, params.Values)
+	})
+}
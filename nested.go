@@ -0,0 +1,141 @@
+package parameters
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// asObject returns the value as an object, maps with non-string keys (msgpack) are converted
+func asObject(val interface{}) (map[string]interface{}, bool) {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		obj := make(map[string]interface{}, len(v))
+		for k, elem := range v {
+			if b, ok := k.([]byte); ok {
+				k = string(b)
+			}
+			obj[fmt.Sprint(k)] = elem
+		}
+		return obj, true
+	default:
+		return nil, false
+	}
+}
+
+// bracketPrefix returns the form key of the nested key in bracket notation ("order.billing" is "order[billing]")
+func bracketPrefix(key string) string {
+	parts := parsePath(key)
+	var prefix strings.Builder
+	prefix.WriteString(parts[0])
+	for _, part := range parts[1:] {
+		prefix.WriteString("[" + part + "]")
+	}
+	return prefix.String()
+}
+
+// bracketObject collects the form keys in bracket notation below the prefix, "billing[zip]" becomes
+// "zip" and "billing[address][line1]" becomes "address[line1]" for the prefix "billing"
+func (p *Params) bracketObject(prefix string) map[string]interface{} {
+	p.ensureParsed()
+	obj := make(map[string]interface{})
+	for k, v := range p.Values {
+		rest, ok := strings.CutPrefix(k, prefix+"[")
+		if !ok {
+			continue
+		}
+		name, nested, found := strings.Cut(rest, "]")
+		if !found || name == "" {
+			continue
+		}
+		obj[name+nested] = v
+	}
+	return obj
+}
+
+// scoped returns params with the values, keeping the settings of these params
+func (p *Params) scoped(values map[string]interface{}) *Params {
+	return &Params{
+		isBinary: p.isBinary,
+		location: p.location,
+		Values:   values,
+
+		durationUnit:      p.durationUnit,
+		negativeDurations: p.negativeDurations,
+		epochUnit:         p.epochUnit,
+		epochTimes:        p.epochTimes,
+
+		maxNumberDigits:   p.maxNumberDigits,
+		bigFloatPrecision: p.bigFloatPrecision,
+		decimalLimits:     p.decimalLimits,
+	}
+}
+
+// SubOk returns the params of the nested object of the key (e.g. "billing" or "order.billing"),
+// from a json or msgpack object or from form keys in bracket notation ("billing[zip]")
+func (p *Params) SubOk(key string) (*Params, bool) {
+	if val, ok := p.Get(key); ok {
+		if obj, isObject := asObject(val); isObject {
+			return p.scoped(obj), true
+		}
+		return p.scoped(map[string]interface{}{}), false
+	}
+	if obj := p.bracketObject(bracketPrefix(key)); len(obj) > 0 {
+		return p.scoped(obj), true
+	}
+	return p.scoped(map[string]interface{}{}), false
+}
+
+// Sub returns the params of the nested object of the key, empty params if there is no such object
+//
+//	zip := params.Sub("billing").GetString("zip")
+func (p *Params) Sub(key string) *Params {
+	sub, _ := p.SubOk(key)
+	return sub
+}
+
+// Each returns the params of every object in the array of the key (e.g. "items"), from a json or
+// msgpack array or from form keys in bracket notation ("items[0][sku]"), elements that are not
+// objects are skipped
+//
+//	for _, item := range params.Each("items") {
+//		sku, quantity := item.GetString("sku"), item.GetInt("quantity")
+//	}
+func (p *Params) Each(key string) []*Params {
+	if val, ok := p.Get(key); ok {
+		switch val.(type) {
+		case string, []byte:
+			return nil
+		}
+		elems, _ := sliceValues(val)
+		items := make([]*Params, 0, len(elems))
+		for _, elem := range elems {
+			if obj, isObject := asObject(elem); isObject {
+				items = append(items, p.scoped(obj))
+			}
+		}
+		return items
+	}
+
+	// Form keys in bracket notation, ordered by index
+	var indexes []int
+	seen := make(map[int]bool)
+	for k := range p.bracketObject(bracketPrefix(key)) {
+		name, _, _ := strings.Cut(k, "[")
+		if i, err := strconv.Atoi(name); err == nil && i >= 0 && !seen[i] {
+			seen[i] = true
+			indexes = append(indexes, i)
+		}
+	}
+	sort.Ints(indexes)
+	items := make([]*Params, 0, len(indexes))
+	for _, i := range indexes {
+		if item, ok := p.SubOk(key + "." + strconv.Itoa(i)); ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
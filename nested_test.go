@@ -0,0 +1,98 @@
+package parameters
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testOrder is the order used by the nested params tests
+var testOrder = map[string]interface{}{
+	"billing": map[string]interface{}{
+		"zip":     "10115",
+		"address": map[string]interface{}{"line1": "Main St 1"},
+	},
+	"items": []interface{}{
+		map[string]interface{}{"sku": "A-1", "quantity": 2},
+		"not an object",
+		map[string]interface{}{"sku": "B-2", "quantity": 1},
+	},
+}
+
+// TestParams_SubAndEach tests the nested params of json, msgpack and bracket notation form requests
+func TestParams_SubAndEach(t *testing.T) {
+	form := url.Values{
+		"billing[zip]":            {"10115"},
+		"billing[address][line1]": {"Main St 1"},
+		"items[1][sku]":           {"B-2"},
+		"items[1][quantity]":      {"1"},
+		"items[0][sku]":           {"A-1"},
+		"items[0][quantity]":      {"2"},
+	}
+	requests := map[string]*Params{
+		"json":    ParseParams(newLimitsRequest(t, "/", "application/json", mustMarshalJSON(t, testOrder))),
+		"msgpack": ParseParams(newLimitsRequest(t, "/", "application/x-msgpack", encodeMsgpack(t, testOrder))),
+		"form": ParseParams(newLimitsRequest(t, "/", "application/x-www-form-urlencoded",
+			[]byte(form.Encode()))),
+	}
+	for name, params := range requests {
+		t.Run(name, func(t *testing.T) {
+			billing, ok := params.SubOk("billing")
+			require.True(t, ok)
+			assert.Equal(t, "10115", billing.GetString("zip"))
+			assert.Equal(t, "Main St 1", billing.Sub("address").GetString("line1"))
+			assert.Equal(t, "Main St 1", params.Sub("billing.address").GetString("line1"))
+
+			items := params.Each("items")
+			require.Len(t, items, 2)
+			assert.Equal(t, "A-1", items[0].GetString("sku"))
+			assert.Equal(t, 2, items[0].GetInt("quantity"))
+			assert.Equal(t, "B-2", items[1].GetString("sku"))
+			assert.Equal(t, 1, items[1].GetInt("quantity"))
+		})
+	}
+}
+
+// TestParams_SubMissing tests the nested params of keys that are not objects
+func TestParams_SubMissing(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"name": "John",
+		"tags": "a,b",
+	}}
+
+	sub, ok := params.SubOk("missing")
+	assert.False(t, ok)
+	require.NotNil(t, sub)
+	assert.Empty(t, sub.GetString("zip"))
+
+	_, ok = params.SubOk("name")
+	assert.False(t, ok)
+
+	assert.Empty(t, params.Each("missing"))
+	assert.Empty(t, params.Each("tags"))
+}
+
+// TestParams_SubSettings tests that nested params keep the settings of the parent
+func TestParams_SubSettings(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"event": map[interface{}]interface{}{"starts": "2024-05-01 10:00:00"},
+	}}
+	loc, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	params.SetLocation(loc)
+
+	starts := params.Sub("event").GetTime("starts")
+	assert.Equal(t, "2024-05-01T10:00:00+02:00", starts.Format(time.RFC3339))
+}
+
+// mustMarshalJSON encodes the value as json
+func mustMarshalJSON(t *testing.T, v interface{}) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return data
+}
@@ -0,0 +1,387 @@
+package parameters
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// AddrOption restricts or normalizes the addresses returned by the network address getters
+type AddrOption int
+
+// The options of the network address getters
+const (
+	// AddrIPv4Only accepts only IPv4 addresses
+	AddrIPv4Only AddrOption = iota + 1
+
+	// AddrIPv6Only accepts only IPv6 addresses
+	AddrIPv6Only
+
+	// AddrRejectPrivate rejects addresses that are not public: private (RFC 1918 and RFC 4193),
+	// link-local and unspecified addresses, and prefixes overlapping such a range
+	AddrRejectPrivate
+
+	// AddrRejectLoopback rejects loopback addresses and prefixes overlapping the loopback range
+	AddrRejectLoopback
+
+	// AddrUnmap converts IPv4-mapped IPv6 addresses ("::ffff:10.0.0.1") into IPv4 addresses
+	AddrUnmap
+)
+
+// ErrAddrNotAllowed is the reason when the address is excluded by the options
+var ErrAddrNotAllowed = errors.New("parameters: address not allowed")
+
+// The address ranges rejected by AddrRejectPrivate and AddrRejectLoopback
+var (
+	nonPublicRanges = []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("172.16.0.0/12"),
+		netip.MustParsePrefix("192.168.0.0/16"),
+		netip.MustParsePrefix("fc00::/7"),
+		netip.MustParsePrefix("169.254.0.0/16"),
+		netip.MustParsePrefix("fe80::/10"),
+		netip.MustParsePrefix("224.0.0.0/24"),
+		netip.MustParsePrefix("ff02::/16"),
+		netip.MustParsePrefix("0.0.0.0/32"),
+		netip.MustParsePrefix("::/128"),
+	}
+	loopbackRanges = []netip.Prefix{
+		netip.MustParsePrefix("127.0.0.0/8"),
+		netip.MustParsePrefix("::1/128"),
+	}
+)
+
+// addrOptions are the options of a getter
+type addrOptions struct {
+	ipv4Only, ipv6Only, rejectPrivate, rejectLoopback, unmap bool
+}
+
+// newAddrOptions collects the options
+func newAddrOptions(opts []AddrOption) addrOptions {
+	var o addrOptions
+	for _, opt := range opts {
+		switch opt {
+		case AddrIPv4Only:
+			o.ipv4Only = true
+		case AddrIPv6Only:
+			o.ipv6Only = true
+		case AddrRejectPrivate:
+			o.rejectPrivate = true
+		case AddrRejectLoopback:
+			o.rejectLoopback = true
+		case AddrUnmap:
+			o.unmap = true
+		}
+	}
+	return o
+}
+
+// target returns the target type name for the base name (e.g. "public IPv4 address")
+func (o addrOptions) target(base string) string {
+	var qualifiers []string
+	if o.rejectPrivate {
+		qualifiers = append(qualifiers, "public")
+	}
+	if o.rejectLoopback {
+		qualifiers = append(qualifiers, "non-loopback")
+	}
+	switch {
+	case o.ipv4Only:
+		base = strings.Replace(base, "IP", "IPv4", 1)
+	case o.ipv6Only:
+		base = strings.Replace(base, "IP", "IPv6", 1)
+	}
+	return strings.Join(append(qualifiers, base), " ")
+}
+
+// check normalizes the address and checks it against the options
+func (o addrOptions) check(addr netip.Addr) (netip.Addr, error) {
+	if o.unmap {
+		addr = addr.Unmap()
+	}
+	unmapped := addr.Unmap()
+	nonPublic := unmapped.IsPrivate() || unmapped.IsLinkLocalUnicast() || unmapped.IsLinkLocalMulticast() ||
+		unmapped.IsUnspecified()
+	switch {
+	case o.ipv4Only && !addr.Is4(),
+		o.ipv6Only && !addr.Is6(),
+		o.rejectPrivate && nonPublic,
+		o.rejectLoopback && unmapped.IsLoopback():
+		return netip.Addr{}, ErrAddrNotAllowed
+	}
+	return addr, nil
+}
+
+// checkPrefix masks and normalizes the prefix, any address of the range must be allowed by the options
+func (o addrOptions) checkPrefix(prefix netip.Prefix) (netip.Prefix, error) {
+	addr, err := o.check(prefix.Addr())
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	bits := prefix.Bits()
+	if addr.Is4() && prefix.Addr().Is4In6() {
+		bits -= 96
+	}
+	if bits < 0 {
+		return netip.Prefix{}, ErrParse
+	}
+	prefix = netip.PrefixFrom(addr, bits).Masked()
+
+	// The ranges are IPv4, IPv4-mapped prefixes are compared unmapped
+	unmapped := prefix
+	if prefix.Addr().Is4In6() && prefix.Bits() >= 96 {
+		unmapped = netip.PrefixFrom(prefix.Addr().Unmap(), prefix.Bits()-96)
+	}
+	if (o.rejectPrivate && overlapsAny(unmapped, nonPublicRanges)) ||
+		(o.rejectLoopback && overlapsAny(unmapped, loopbackRanges)) {
+		return netip.Prefix{}, ErrAddrNotAllowed
+	}
+	return prefix, nil
+}
+
+// overlapsAny returns true if the prefix overlaps any of the ranges
+func overlapsAny(prefix netip.Prefix, ranges []netip.Prefix) bool {
+	for _, r := range ranges {
+		if r.Overlaps(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// toAddr converts the value to an address
+func toAddr(val interface{}) (netip.Addr, error) {
+	switch v := val.(type) {
+	case netip.Addr:
+		return v, nil
+	case net.IP:
+		// net.IP keeps IPv4 addresses in the 16 byte form
+		if v4 := v.To4(); v4 != nil {
+			v = v4
+		}
+		if addr, ok := netip.AddrFromSlice(v); ok {
+			return addr, nil
+		}
+		return netip.Addr{}, ErrParse
+	case []byte:
+		// Parsed as text like strings, raw bytes are ambiguous ("abcd" would be 97.98.99.100)
+		return toAddr(string(v))
+	case string:
+		addr, err := netip.ParseAddr(strings.TrimSpace(v))
+		if err != nil {
+			return netip.Addr{}, ErrParse
+		}
+		return addr, nil
+	default:
+		return netip.Addr{}, ErrWrongType
+	}
+}
+
+// toPrefix converts the value to a prefix, a single address becomes a prefix of its full length
+func toPrefix(val interface{}) (netip.Prefix, error) {
+	switch v := val.(type) {
+	case netip.Prefix:
+		return v, nil
+	case []byte:
+		return toPrefix(string(v))
+	case string:
+		s := strings.TrimSpace(v)
+		if !strings.Contains(s, "/") {
+			addr, err := netip.ParseAddr(s)
+			if err != nil {
+				return netip.Prefix{}, ErrParse
+			}
+			return netip.PrefixFrom(addr, addr.BitLen()), nil
+		}
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Prefix{}, ErrParse
+		}
+		return prefix, nil
+	default:
+		return netip.Prefix{}, ErrWrongType
+	}
+}
+
+// toAddrPort converts the value to an address and port ("10.0.0.1:8080" or "[::1]:8080")
+func toAddrPort(val interface{}) (netip.AddrPort, error) {
+	switch v := val.(type) {
+	case netip.AddrPort:
+		return v, nil
+	case []byte:
+		return toAddrPort(string(v))
+	case string:
+		addrPort, err := netip.ParseAddrPort(strings.TrimSpace(v))
+		if err != nil {
+			return netip.AddrPort{}, ErrParse
+		}
+		return addrPort, nil
+	default:
+		return netip.AddrPort{}, ErrWrongType
+	}
+}
+
+// convertAddr converts the value to an address allowed by the options
+func convertAddr(key string, val interface{}, o addrOptions) (netip.Addr, error) {
+	addr, err := toAddr(val)
+	if err == nil {
+		addr, err = o.check(addr)
+	}
+	if err != nil {
+		return netip.Addr{}, newConversionError(key, val, o.target("IP address"), err)
+	}
+	return addr, nil
+}
+
+// convertPrefix converts the value to a masked prefix with a range allowed by the options
+func convertPrefix(key string, val interface{}, o addrOptions) (netip.Prefix, error) {
+	prefix, err := toPrefix(val)
+	if err == nil {
+		prefix, err = o.checkPrefix(prefix)
+	}
+	if err != nil {
+		return netip.Prefix{}, newConversionError(key, val, o.target("IP prefix"), err)
+	}
+	return prefix, nil
+}
+
+// convertAddrPort converts the value to an address and port with an address allowed by the options
+func convertAddrPort(key string, val interface{}, o addrOptions) (netip.AddrPort, error) {
+	addrPort, err := toAddrPort(val)
+	if err == nil {
+		var addr netip.Addr
+		if addr, err = o.check(addrPort.Addr()); err == nil {
+			addrPort = netip.AddrPortFrom(addr, addrPort.Port())
+		}
+	}
+	if err != nil {
+		return netip.AddrPort{}, newConversionError(key, val, o.target("IP address and port"), err)
+	}
+	return addrPort, nil
+}
+
+// GetIPE get param by key, return IP address or a *ConversionError
+func (p *Params) GetIPE(key string, opts ...AddrOption) (netip.Addr, error) {
+	o := newAddrOptions(opts)
+	val, err := p.getValue(key, o.target("IP address"))
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return convertAddr(key, val, o)
+}
+
+// GetIPOk get param by key, return IP address
+//
+//	ip, ok := params.GetIPOk("ip", parameters.AddrIPv4Only, parameters.AddrRejectPrivate)
+func (p *Params) GetIPOk(key string, opts ...AddrOption) (netip.Addr, bool) {
+	val, err := p.GetIPE(key, opts...)
+	return val, err == nil
+}
+
+// GetIP get param by key, return IP address
+func (p *Params) GetIP(key string, opts ...AddrOption) netip.Addr {
+	val, _ := p.GetIPOk(key, opts...)
+	return val
+}
+
+// GetIPSliceE get param by key, return slice of IP addresses or a *ConversionError
+func (p *Params) GetIPSliceE(key string, opts ...AddrOption) ([]netip.Addr, error) {
+	o := newAddrOptions(opts)
+	return getSlice(p, key, "[]"+o.target("IP address"), func(elemKey string, val interface{}) (netip.Addr, error) {
+		return convertAddr(elemKey, val, o)
+	})
+}
+
+// GetIPSliceOk get param by key, return slice of IP addresses
+func (p *Params) GetIPSliceOk(key string, opts ...AddrOption) ([]netip.Addr, bool) {
+	return sliceOk(p.GetIPSliceE(key, opts...))
+}
+
+// GetIPSlice get param by key, return slice of IP addresses
+func (p *Params) GetIPSlice(key string, opts ...AddrOption) []netip.Addr {
+	val, _ := p.GetIPSliceOk(key, opts...)
+	return val
+}
+
+// GetPrefixE get param by key, return IP prefix (CIDR range) or a *ConversionError
+func (p *Params) GetPrefixE(key string, opts ...AddrOption) (netip.Prefix, error) {
+	o := newAddrOptions(opts)
+	val, err := p.getValue(key, o.target("IP prefix"))
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return convertPrefix(key, val, o)
+}
+
+// GetPrefixOk get param by key, return IP prefix (CIDR range)
+func (p *Params) GetPrefixOk(key string, opts ...AddrOption) (netip.Prefix, bool) {
+	val, err := p.GetPrefixE(key, opts...)
+	return val, err == nil
+}
+
+// GetPrefix get param by key, return IP prefix (CIDR range)
+func (p *Params) GetPrefix(key string, opts ...AddrOption) netip.Prefix {
+	val, _ := p.GetPrefixOk(key, opts...)
+	return val
+}
+
+// GetPrefixSliceE get param by key, return slice of IP prefixes or a *ConversionError
+func (p *Params) GetPrefixSliceE(key string, opts ...AddrOption) ([]netip.Prefix, error) {
+	o := newAddrOptions(opts)
+	return getSlice(p, key, "[]"+o.target("IP prefix"), func(elemKey string, val interface{}) (netip.Prefix, error) {
+		return convertPrefix(elemKey, val, o)
+	})
+}
+
+// GetPrefixSliceOk get param by key, return slice of IP prefixes
+func (p *Params) GetPrefixSliceOk(key string, opts ...AddrOption) ([]netip.Prefix, bool) {
+	return sliceOk(p.GetPrefixSliceE(key, opts...))
+}
+
+// GetPrefixSlice get param by key, return slice of IP prefixes
+func (p *Params) GetPrefixSlice(key string, opts ...AddrOption) []netip.Prefix {
+	val, _ := p.GetPrefixSliceOk(key, opts...)
+	return val
+}
+
+// GetAddrPortE get param by key, return IP address and port or a *ConversionError
+func (p *Params) GetAddrPortE(key string, opts ...AddrOption) (netip.AddrPort, error) {
+	o := newAddrOptions(opts)
+	val, err := p.getValue(key, o.target("IP address and port"))
+	if err != nil {
+		return netip.AddrPort{}, err
+	}
+	return convertAddrPort(key, val, o)
+}
+
+// GetAddrPortOk get param by key, return IP address and port
+func (p *Params) GetAddrPortOk(key string, opts ...AddrOption) (netip.AddrPort, bool) {
+	val, err := p.GetAddrPortE(key, opts...)
+	return val, err == nil
+}
+
+// GetAddrPort get param by key, return IP address and port
+func (p *Params) GetAddrPort(key string, opts ...AddrOption) netip.AddrPort {
+	val, _ := p.GetAddrPortOk(key, opts...)
+	return val
+}
+
+// GetAddrPortSliceE get param by key, return slice of IP addresses and ports or a *ConversionError
+func (p *Params) GetAddrPortSliceE(key string, opts ...AddrOption) ([]netip.AddrPort, error) {
+	o := newAddrOptions(opts)
+	return getSlice(p, key, "[]"+o.target("IP address and port"), func(elemKey string, val interface{}) (netip.AddrPort, error) {
+		return convertAddrPort(elemKey, val, o)
+	})
+}
+
+// GetAddrPortSliceOk get param by key, return slice of IP addresses and ports
+func (p *Params) GetAddrPortSliceOk(key string, opts ...AddrOption) ([]netip.AddrPort, bool) {
+	return sliceOk(p.GetAddrPortSliceE(key, opts...))
+}
+
+// GetAddrPortSlice get param by key, return slice of IP addresses and ports
+func (p *Params) GetAddrPortSlice(key string, opts ...AddrOption) []netip.AddrPort {
+	val, _ := p.GetAddrPortSliceOk(key, opts...)
+	return val
+}
@@ -0,0 +1,217 @@
+package parameters
+
+import (
+	"net"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParams_GetIP tests the IP address getters and options
+func TestParams_GetIP(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"public":   "8.8.8.8",
+		"private":  "10.0.0.1",
+		"loopback": "127.0.0.1",
+		"ipv6":     "2001:db8::1",
+		"mapped":   "::ffff:8.8.4.4",
+		"bytes":    []byte("1.1.1.1"),
+		"raw":      []byte("abcd"),
+		"net_ip":   net.ParseIP("1.0.0.1"),
+		"invalid":  "8.8.8",
+		"number":   42,
+
+		"unspecified": "0.0.0.0",
+		"link_local":  "169.254.169.254",
+		"link_local6": "fe80::1",
+	}}
+
+	tests := []struct {
+		name     string
+		key      string
+		opts     []AddrOption
+		expected string
+		reason   error
+	}{
+		{"public", "public", nil, "8.8.8.8", nil},
+		{"ipv6", "ipv6", nil, "2001:db8::1", nil},
+		{"bytes", "bytes", nil, "1.1.1.1", nil},
+		{"raw bytes", "raw", nil, "", ErrParse},
+		{"net.IP", "net_ip", nil, "1.0.0.1", nil},
+		{"mapped kept", "mapped", nil, "::ffff:8.8.4.4", nil},
+		{"mapped unmapped", "mapped", []AddrOption{AddrUnmap}, "8.8.4.4", nil},
+		{"mapped ipv4 only", "mapped", []AddrOption{AddrIPv4Only}, "", ErrAddrNotAllowed},
+		{"mapped unmapped ipv4 only", "mapped", []AddrOption{AddrUnmap, AddrIPv4Only}, "8.8.4.4", nil},
+		{"ipv4 only", "ipv6", []AddrOption{AddrIPv4Only}, "", ErrAddrNotAllowed},
+		{"ipv6 only", "public", []AddrOption{AddrIPv6Only}, "", ErrAddrNotAllowed},
+		{"reject private", "private", []AddrOption{AddrRejectPrivate}, "", ErrAddrNotAllowed},
+		{"reject loopback", "loopback", []AddrOption{AddrRejectLoopback}, "", ErrAddrNotAllowed},
+		{"loopback allowed", "loopback", []AddrOption{AddrRejectPrivate}, "127.0.0.1", nil},
+		{"reject unspecified", "unspecified", []AddrOption{AddrRejectPrivate}, "", ErrAddrNotAllowed},
+		{"reject link-local", "link_local", []AddrOption{AddrRejectPrivate}, "", ErrAddrNotAllowed},
+		{"reject link-local ipv6", "link_local6", []AddrOption{AddrRejectPrivate}, "", ErrAddrNotAllowed},
+		{"invalid", "invalid", nil, "", ErrParse},
+		{"wrong type", "number", nil, "", ErrWrongType},
+		{"missing", "missing", nil, "", ErrParamNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := params.GetIPE(tt.key, tt.opts...)
+			if tt.reason != nil {
+				require.ErrorIs(t, err, tt.reason)
+				_, ok := params.GetIPOk(tt.key, tt.opts...)
+				assert.False(t, ok)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, addr.String())
+			assert.Equal(t, addr, params.GetIP(tt.key, tt.opts...))
+		})
+	}
+
+	_, err := params.GetIPE("private", AddrIPv4Only, AddrRejectPrivate)
+	assert.Equal(t, "private must be a valid public IPv4 address", err.Error())
+	_, err = params.GetIPE("invalid")
+	assert.Equal(t, "invalid must be an IP address", err.Error())
+}
+
+// TestParams_GetPrefix tests the IP prefix getters
+func TestParams_GetPrefix(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"range":   "192.168.0.0/16",
+		"single":  "8.8.8.8",
+		"ipv6":    "2001:db8::/32",
+		"mapped":  "::ffff:10.0.0.0/104",
+		"invalid": "10.0.0.0/33",
+	}}
+
+	assert.Equal(t, "192.168.0.0/16", params.GetPrefix("range").String())
+	assert.Equal(t, "8.8.8.8/32", params.GetPrefix("single").String())
+	assert.Equal(t, "2001:db8::/32", params.GetPrefix("ipv6").String())
+	assert.Equal(t, "10.0.0.0/8", params.GetPrefix("mapped", AddrUnmap).String())
+
+	_, ok := params.GetPrefixOk("range", AddrRejectPrivate)
+	assert.False(t, ok)
+	_, ok = params.GetPrefixOk("ipv6", AddrIPv4Only)
+	assert.False(t, ok)
+
+	_, err := params.GetPrefixE("invalid")
+	require.ErrorIs(t, err, ErrParse)
+	assert.Equal(t, "invalid must be an IP prefix (CIDR)", err.Error())
+
+	// The prefixes are masked and the whole range must be allowed
+	params = &Params{Values: map[string]interface{}{
+		"unmasked":  "10.1.2.3/8",
+		"covering":  "8.0.0.0/6",
+		"all":       "0.0.0.0/0",
+		"all6":      "::/0",
+		"loopback":  "126.0.0.0/7",
+		"link":      "169.254.0.0/24",
+		"public":    "8.8.8.0/24",
+		"mapped":    "::ffff:8.0.0.0/102",
+		"mapped_ok": "::ffff:8.8.8.0/120",
+	}}
+	assert.Equal(t, "10.0.0.0/8", params.GetPrefix("unmasked").String())
+	for _, key := range []string{"covering", "all", "all6", "link", "mapped"} {
+		_, err = params.GetPrefixE(key, AddrRejectPrivate)
+		require.ErrorIs(t, err, ErrAddrNotAllowed, key)
+	}
+	_, err = params.GetPrefixE("loopback", AddrRejectLoopback)
+	require.ErrorIs(t, err, ErrAddrNotAllowed)
+	assert.Equal(t, "8.8.8.0/24", params.GetPrefix("public", AddrRejectPrivate, AddrRejectLoopback).String())
+	assert.Equal(t, "::ffff:8.8.8.0/120", params.GetPrefix("mapped_ok", AddrRejectPrivate).String())
+}
+
+// TestParams_GetAddrPort tests the address and port getters
+func TestParams_GetAddrPort(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"ipv4":    "10.0.0.1:8080",
+		"ipv6":    "[::1]:443",
+		"host":    "example.com:80",
+		"no_port": "10.0.0.1",
+	}}
+
+	addrPort := params.GetAddrPort("ipv4")
+	assert.Equal(t, "10.0.0.1", addrPort.Addr().String())
+	assert.Equal(t, uint16(8080), addrPort.Port())
+	assert.Equal(t, "[::1]:443", params.GetAddrPort("ipv6").String())
+
+	_, ok := params.GetAddrPortOk("ipv6", AddrRejectLoopback)
+	assert.False(t, ok)
+	_, ok = params.GetAddrPortOk("host")
+	assert.False(t, ok)
+	_, ok = params.GetAddrPortOk("no_port")
+	assert.False(t, ok)
+}
+
+// TestParams_GetIPSlice tests the network address slice getters
+func TestParams_GetIPSlice(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"ips":      []interface{}{"8.8.8.8", "2001:db8::1"},
+		"csv":      "1.1.1.1, 10.0.0.1",
+		"prefixes": []interface{}{"10.0.0.0/8", "fd00::/8"},
+		"backends": []interface{}{"10.0.0.1:80", "10.0.0.2:80"},
+	}}
+
+	assert.Len(t, params.GetIPSlice("ips"), 2)
+	assert.Equal(t, []netip.Addr{netip.MustParseAddr("1.1.1.1"), netip.MustParseAddr("10.0.0.1")}, params.GetIPSlice("csv"))
+
+	_, err := params.GetIPSliceE("csv", AddrRejectPrivate)
+	var convErr *ConversionError
+	require.ErrorAs(t, err, &convErr)
+	assert.Equal(t, "csv.1", convErr.Key)
+
+	assert.Len(t, params.GetPrefixSlice("prefixes"), 2)
+	_, ok := params.GetPrefixSliceOk("prefixes", AddrRejectPrivate)
+	assert.False(t, ok)
+
+	backends, ok := params.GetAddrPortSliceOk("backends")
+	require.True(t, ok)
+	assert.Equal(t, "10.0.0.2:80", backends[1].String())
+}
+
+// TestImbue_NetIP tests that Imbue sets the network address fields
+func TestImbue_NetIP(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"client":  "203.0.113.7",
+		"network": "203.0.113.0/24",
+		"listen":  "0.0.0.0:8080",
+		"allowed": []interface{}{"10.0.0.0/8"},
+	}}
+
+	type testType struct {
+		Client  netip.Addr
+		Network netip.Prefix
+		Listen  netip.AddrPort
+		Allowed []netip.Prefix
+	}
+
+	var obj testType
+	params.Imbue(&obj)
+
+	assert.Equal(t, "203.0.113.7", obj.Client.String())
+	assert.Equal(t, "203.0.113.0/24", obj.Network.String())
+	assert.Equal(t, "0.0.0.0:8080", obj.Listen.String())
+	assert.Equal(t, []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}, obj.Allowed)
+}
+
+// TestImbue_IPFields tests that Imbue fills fields named with the IP abbreviation
+func TestImbue_IPFields(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"ip":        "198.51.100.1",
+		"client_ip": "203.0.113.7",
+	}}
+
+	type testType struct {
+		IP       netip.Addr
+		ClientIP netip.Addr
+	}
+
+	var obj testType
+	params.Imbue(&obj)
+
+	assert.Equal(t, "198.51.100.1", obj.IP.String())
+	assert.Equal(t, "203.0.113.7", obj.ClientIP.String())
+}
@@ -1,23 +1,32 @@
 /*
 Package parameters parses json, msg pack, or multi-part form data into a parameters object
+
+Breaking change: json numbers are decoded as json.Number (not float64) so every digit is kept for the
+arbitrary-precision getters. The typed getters (GetFloat, GetInt, ...) convert json.Number, but code that
+asserts the raw values of Get, GetJSON, Values or a CustomTypeSetter to float64 must convert json.Number:
+
+	if n, ok := val.(json.Number); ok {
+		f, err := n.Float64()
+	}
 */
 package parameters
 
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log"
-	"math"
+	"mime"
 	"mime/multipart"
 	"net/http"
+	"net/url"
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -54,8 +63,25 @@ type paramKey string
 
 // Params is the parameter values
 type Params struct {
-	isBinary bool
-	Values   map[string]interface{}
+	isBinary    bool
+	lazy        *lazyLoader
+	parseErr    error
+	fileInfos   map[*multipart.FileHeader]*FileInfo
+	fileInfosMu sync.Mutex
+	attachments []*Attachment
+	location    *time.Location
+	Values      map[string]interface{}
+
+	// The settings of the duration and time getters
+	durationUnit      time.Duration
+	negativeDurations bool
+	epochUnit         EpochUnit
+	epochTimes        bool
+
+	// The settings of the arbitrary-precision getters
+	maxNumberDigits   int
+	bigFloatPrecision uint
+	decimalLimits     *DecimalLimits
 }
 
 // CustomTypeHandler custom type handler
@@ -66,32 +92,23 @@ type CustomTypeHandler func(field *reflect.Value, value interface{}) error
 // CustomTypeSetter is used when Imbue is called on an object to handle unknown types
 var CustomTypeSetter CustomTypeHandler
 
-// Get the param by key, return interface
+// Get the param by key or path ("items.0.sku", "items[0].sku" or "version\.major" for a key with a dot),
+// return interface
 func (p *Params) Get(key string) (val interface{}, ok bool) {
-	keys := strings.Split(key, ".")
-	root := p.Values
-	count := len(keys)
-	for i := 0; i < count; i++ {
-		val, ok = root[keys[i]]
-		if ok && i < count-1 {
-			root = val.(map[string]interface{})
+	// Path parameters of lazy params are available without parsing the body
+	if p.lazy != nil && !p.lazy.parsed.Load() {
+		if val, ok = lookup(p.lazy.path, key); ok {
+			return val, ok
 		}
 	}
-	return val, ok
+	p.ensureParsed()
+	return lookup(p.Values, key)
 }
 
 // GetFloatOk get param by key, return float
 func (p *Params) GetFloatOk(key string) (float64, bool) {
-	val, ok := p.Get(key)
-	if stringValue, stringOk := val.(string); stringOk {
-		var err error
-		val, err = strconv.ParseFloat(stringValue, 64)
-		ok = err == nil
-	}
-	if ok && val != nil {
-		return val.(float64), true
-	}
-	return 0, true
+	val, err := p.GetFloatE(key)
+	return val, err == nil
 }
 
 // GetFloat get param by key, return float
@@ -100,80 +117,21 @@ func (p *Params) GetFloat(key string) float64 {
 	return val
 }
 
+// GetFloat32Ok get param by key, return float
+func (p *Params) GetFloat32Ok(key string) (float32, bool) {
+	val, err := p.GetFloat32E(key)
+	return val, err == nil
+}
+
+// GetFloat32 get param by key, return float
+func (p *Params) GetFloat32(key string) float32 {
+	val, _ := p.GetFloat32Ok(key)
+	return val
+}
+
 // GetFloatSliceOk get param by key, return slice of floats
 func (p *Params) GetFloatSliceOk(key string) ([]float64, bool) {
-	val, ok := p.Get(key)
-	if ok {
-		switch v := val.(type) {
-		case []float64:
-			return v, true
-		case string:
-			if v == "" {
-				return []float64{}, true
-			}
-			raw := strings.Split(v, ",")
-			slice := make([]float64, 0, len(raw))
-			for _, k := range raw {
-				if num, err := strconv.ParseFloat(strings.TrimSpace(k), 64); err == nil {
-					// Reject NaN and Infinity values
-					if math.IsNaN(num) || math.IsInf(num, 0) {
-						return []float64{}, false
-					}
-					slice = append(slice, num)
-				} else {
-					return []float64{}, false
-				}
-			}
-			return slice, true
-		case []byte:
-			vStr := string(v)
-			if vStr == "" {
-				return []float64{}, true
-			}
-			raw := strings.Split(vStr, ",")
-			slice := make([]float64, 0, len(raw))
-			for _, k := range raw {
-				if num, err := strconv.ParseFloat(strings.TrimSpace(k), 64); err == nil {
-					// Reject NaN and Infinity values
-					if math.IsNaN(num) || math.IsInf(num, 0) {
-						return []float64{}, false
-					}
-					slice = append(slice, num)
-				} else {
-					return []float64{}, false
-				}
-			}
-			return slice, true
-		case []interface{}:
-			slice := make([]float64, 0, len(v))
-			for _, k := range v {
-				switch innerVal := k.(type) {
-				case float64:
-					// Reject NaN and Infinity values
-					if math.IsNaN(innerVal) || math.IsInf(innerVal, 0) {
-						return []float64{}, false
-					}
-					slice = append(slice, innerVal)
-				case string:
-					if num, err := strconv.ParseFloat(innerVal, 64); err == nil {
-						// Reject NaN and Infinity values
-						if math.IsNaN(num) || math.IsInf(num, 0) {
-							return []float64{}, false
-						}
-						slice = append(slice, num)
-					} else {
-						return []float64{}, false
-					}
-				default:
-					return []float64{}, false
-				}
-			}
-			return slice, true
-		default:
-			return []float64{}, false
-		}
-	}
-	return []float64{}, false
+	return sliceOk(p.GetFloatSliceE(key))
 }
 
 // GetFloatSlice get param by key, return slice of floats
@@ -184,19 +142,8 @@ func (p *Params) GetFloatSlice(key string) []float64 {
 
 // GetBoolOk get param by key, return boolean
 func (p *Params) GetBoolOk(key string) (bool, bool) {
-	val, ok := p.Get(key)
-	if ok {
-		if b, isBool := val.(bool); isBool {
-			return b, true
-		} else if i, isInt := p.GetIntOk(key); isInt {
-			if i == 0 {
-				return false, true
-			}
-
-			return true, true
-		}
-	}
-	return false, false
+	val, err := p.GetBoolE(key)
+	return val, err == nil
 }
 
 // GetBool get param by key, return boolean
@@ -205,57 +152,21 @@ func (p *Params) GetBool(key string) bool {
 	return val
 }
 
+// GetBoolSliceOk get param by key, return slice of booleans
+func (p *Params) GetBoolSliceOk(key string) ([]bool, bool) {
+	return sliceOk(p.GetBoolSliceE(key))
+}
+
+// GetBoolSlice get param by key, return slice of booleans
+func (p *Params) GetBoolSlice(key string) []bool {
+	val, _ := p.GetBoolSliceOk(key)
+	return val
+}
+
 // GetIntOk get param by key, return integer
 func (p *Params) GetIntOk(key string) (int, bool) {
-	val, ok := p.Get(key)
-	if !ok || val == nil {
-		return 0, false
-	}
-
-	switch v := val.(type) {
-	case int:
-		return v, true
-	case int8, int16, int32, int64:
-		i := reflect.ValueOf(v).Int()
-		if i >= int64(math.MinInt) && i <= int64(math.MaxInt) {
-			return int(i), true
-		}
-		return 0, false // Overflow
-	case uint, uint8, uint16, uint32, uint64:
-		u := reflect.ValueOf(v).Uint()
-		if u <= uint64(math.MaxInt) {
-			return int(u), true
-		}
-		return 0, false // Overflow
-	case float32, float64:
-		f := reflect.ValueOf(v).Float()
-		if f >= float64(math.MinInt) && f <= float64(math.MaxInt) && f == math.Trunc(f) {
-			if f > MaxSafeInt || f < -MaxSafeInt {
-				return 0, false // Value exceeds safe integer range
-			}
-			return int(f), true
-		}
-		return 0, false // Overflow or non-integer float
-	case string:
-		if parsedInt, err := strconv.ParseInt(v, 10, 64); err == nil {
-			if parsedInt >= int64(math.MinInt) && parsedInt <= int64(math.MaxInt) {
-				return int(parsedInt), true
-			}
-			return 0, false // Overflow
-		}
-		return 0, false // Parsing failed
-	case []byte:
-		s := string(v)
-		if parsedInt, err := strconv.ParseInt(s, 10, 64); err == nil {
-			if parsedInt >= int64(math.MinInt) && parsedInt <= int64(math.MaxInt) {
-				return int(parsedInt), true
-			}
-			return 0, false // Overflow
-		}
-		return 0, false // Parsing failed
-	default:
-		return 0, false
-	}
+	val, err := p.GetIntE(key)
+	return val, err == nil
 }
 
 // GetInt get param by key, return integer
@@ -266,13 +177,8 @@ func (p *Params) GetInt(key string) int {
 
 // GetInt8Ok get param by key, return integer
 func (p *Params) GetInt8Ok(key string) (int8, bool) {
-	val, ok := p.GetIntOk(key)
-
-	if !ok || val < math.MinInt8 || val > math.MaxInt8 {
-		return 0, false
-	}
-
-	return int8(val), true
+	val, err := p.GetInt8E(key)
+	return val, err == nil
 }
 
 // GetInt8 get param by key, return integer
@@ -283,13 +189,8 @@ func (p *Params) GetInt8(key string) int8 {
 
 // GetInt16Ok get param by key, return integer
 func (p *Params) GetInt16Ok(key string) (int16, bool) {
-	val, ok := p.GetIntOk(key)
-
-	if !ok || val < math.MinInt16 || val > math.MaxInt16 {
-		return 0, false
-	}
-
-	return int16(val), true
+	val, err := p.GetInt16E(key)
+	return val, err == nil
 }
 
 // GetInt16 get param by key, return integer
@@ -300,13 +201,8 @@ func (p *Params) GetInt16(key string) int16 {
 
 // GetInt32Ok get param by key, return integer
 func (p *Params) GetInt32Ok(key string) (int32, bool) {
-	val, ok := p.GetIntOk(key)
-
-	if !ok || val < math.MinInt32 || val > math.MaxInt32 {
-		return 0, false
-	}
-
-	return int32(val), true
+	val, err := p.GetInt32E(key)
+	return val, err == nil
 }
 
 // GetInt32 get param by key, return integer
@@ -317,45 +213,8 @@ func (p *Params) GetInt32(key string) int32 {
 
 // GetInt64Ok get param by key, return integer
 func (p *Params) GetInt64Ok(key string) (int64, bool) {
-	val, ok := p.Get(key)
-	if !ok || val == nil {
-		return 0, false
-	}
-
-	switch v := val.(type) {
-	case int64:
-		return v, true
-	case int, int8, int16, int32:
-		return reflect.ValueOf(v).Int(), true
-	case uint, uint8, uint16, uint32, uint64:
-		u := reflect.ValueOf(v).Uint()
-		if u <= uint64(math.MaxInt64) {
-			return int64(u), true
-		}
-		return 0, false // Overflow
-	case float32, float64:
-		f := reflect.ValueOf(v).Float()
-		if f >= float64(math.MinInt64) && f <= float64(math.MaxInt64) {
-			// Check if the float is an integer value
-			if f == math.Trunc(f) {
-				return int64(f), true
-			}
-		}
-		return 0, false // Overflow or non-integer float
-	case string:
-		if parsedInt, err := strconv.ParseInt(v, 10, 64); err == nil {
-			return parsedInt, true
-		}
-		return 0, false // Parsing failed
-	case []byte:
-		s := string(v)
-		if parsedInt, err := strconv.ParseInt(s, 10, 64); err == nil {
-			return parsedInt, true
-		}
-		return 0, false // Parsing failed
-	default:
-		return 0, false
-	}
+	val, err := p.GetInt64E(key)
+	return val, err == nil
 }
 
 // GetInt64 get param by key, return integer
@@ -366,78 +225,7 @@ func (p *Params) GetInt64(key string) int64 {
 
 // GetIntSliceOk get param by key, return slice of integers
 func (p *Params) GetIntSliceOk(key string) ([]int, bool) {
-	val, ok := p.Get(key)
-	if ok {
-		switch v := val.(type) {
-		case []int:
-			return v, true
-		case []byte:
-			valStr := string(v)
-			raw := strings.Split(valStr, ",")
-			slice := make([]int, 0, len(raw))
-			for _, k := range raw {
-				if num, err := strconv.ParseInt(k, 10, 64); err == nil {
-					if num >= int64(math.MinInt) && num <= int64(math.MaxInt) {
-						slice = append(slice, int(num))
-					} else {
-						return slice, false
-					}
-				} else {
-					return slice, false
-				}
-			}
-			return slice, true
-		case string:
-			if len(v) > 0 {
-				raw := strings.Split(v, ",")
-				slice := make([]int, 0, len(raw))
-				for _, k := range raw {
-					if num, err := strconv.ParseInt(k, 10, 64); err == nil {
-						if num >= int64(math.MinInt) && num <= int64(math.MaxInt) {
-							slice = append(slice, int(num))
-						} else {
-							return slice, false
-						}
-					} else {
-						return slice, false
-					}
-				}
-				return slice, true
-			}
-			return nil, true
-		case []interface{}:
-			raw := v
-			slice := make([]int, 0, len(raw))
-			for _, k := range raw {
-				switch num := k.(type) {
-				case int:
-					slice = append(slice, num)
-				case float64:
-					if num >= float64(math.MinInt) && num <= float64(math.MaxInt) {
-						slice = append(slice, int(num))
-					} else {
-						return slice, false
-					}
-				case string:
-					if parsed, err := strconv.ParseInt(num, 10, 64); err == nil {
-						if parsed >= int64(math.MinInt) && parsed <= int64(math.MaxInt) {
-							slice = append(slice, int(parsed))
-						} else {
-							return slice, false
-						}
-					} else {
-						return slice, false
-					}
-				default:
-					return slice, false
-				}
-			}
-			return slice, true
-		default:
-			return nil, true
-		}
-	}
-	return []int{}, false
+	return sliceOk(p.GetIntSliceE(key))
 }
 
 // GetIntSlice get param by key, return slice of integers
@@ -446,46 +234,80 @@ func (p *Params) GetIntSlice(key string) []int {
 	return val
 }
 
+// GetInt32SliceOk get param by key, return slice of integers
+func (p *Params) GetInt32SliceOk(key string) ([]int32, bool) {
+	return sliceOk(p.GetInt32SliceE(key))
+}
+
+// GetInt32Slice get param by key, return slice of integers
+func (p *Params) GetInt32Slice(key string) []int32 {
+	val, _ := p.GetInt32SliceOk(key)
+	return val
+}
+
+// GetInt64SliceOk get param by key, return slice of integers
+func (p *Params) GetInt64SliceOk(key string) ([]int64, bool) {
+	return sliceOk(p.GetInt64SliceE(key))
+}
+
+// GetInt64Slice get param by key, return slice of integers
+func (p *Params) GetInt64Slice(key string) []int64 {
+	val, _ := p.GetInt64SliceOk(key)
+	return val
+}
+
+// GetUintOk get param by key, return unsigned integer
+func (p *Params) GetUintOk(key string) (uint, bool) {
+	val, err := p.GetUintE(key)
+	return val, err == nil
+}
+
+// GetUint get param by key, return unsigned integer
+func (p *Params) GetUint(key string) uint {
+	val, _ := p.GetUintOk(key)
+	return val
+}
+
+// GetUint8Ok get param by key, return unsigned integer
+func (p *Params) GetUint8Ok(key string) (uint8, bool) {
+	val, err := p.GetUint8E(key)
+	return val, err == nil
+}
+
+// GetUint8 get param by key, return unsigned integer
+func (p *Params) GetUint8(key string) uint8 {
+	val, _ := p.GetUint8Ok(key)
+	return val
+}
+
+// GetUint16Ok get param by key, return unsigned integer
+func (p *Params) GetUint16Ok(key string) (uint16, bool) {
+	val, err := p.GetUint16E(key)
+	return val, err == nil
+}
+
+// GetUint16 get param by key, return unsigned integer
+func (p *Params) GetUint16(key string) uint16 {
+	val, _ := p.GetUint16Ok(key)
+	return val
+}
+
+// GetUint32Ok get param by key, return unsigned integer
+func (p *Params) GetUint32Ok(key string) (uint32, bool) {
+	val, err := p.GetUint32E(key)
+	return val, err == nil
+}
+
+// GetUint32 get param by key, return unsigned integer
+func (p *Params) GetUint32(key string) uint32 {
+	val, _ := p.GetUint32Ok(key)
+	return val
+}
+
 // GetUint64Ok get param by key, return unsigned integer
 func (p *Params) GetUint64Ok(key string) (uint64, bool) {
-	val, ok := p.Get(key)
-	if !ok || val == nil {
-		return 0, false
-	}
-
-	switch v := val.(type) {
-	case uint64:
-		return v, true
-	case uint, uint8, uint16, uint32:
-		return reflect.ValueOf(v).Uint(), true
-	case int, int8, int16, int32, int64:
-		i := reflect.ValueOf(v).Int()
-		if i >= 0 {
-			return uint64(i), true
-		}
-		return 0, false
-	case float32, float64:
-		f := reflect.ValueOf(v).Float()
-		if f >= 0 && f == math.Trunc(f) && f <= float64(math.MaxUint64) {
-			return uint64(f), true
-		}
-		return 0, false
-	case string:
-		if parsedUint, err := strconv.ParseUint(v, 10, 64); err == nil {
-			return parsedUint, true
-		}
-		// Do not parse strings as float64; return false if parsing fails
-		return 0, false
-	case []byte:
-		s := string(v)
-		if parsedUint, err := strconv.ParseUint(s, 10, 64); err == nil {
-			return parsedUint, true
-		}
-		// Do not parse []byte as float64; return false if parsing fails
-		return 0, false
-	default:
-		return 0, false
-	}
+	val, err := p.GetUint64E(key)
+	return val, err == nil
 }
 
 // GetUint64 get param by key, return unsigned integer
@@ -496,19 +318,7 @@ func (p *Params) GetUint64(key string) uint64 {
 
 // GetUint64SliceOk get param by key, return slice of unsigned integers
 func (p *Params) GetUint64SliceOk(key string) ([]uint64, bool) {
-	if raw, ok := p.GetIntSliceOk(key); ok {
-		slice := make([]uint64, len(raw))
-		for i, num := range raw {
-			if num < 0 {
-				// Return empty slice instead of nil
-				return []uint64{}, false
-			}
-			slice[i] = uint64(num)
-		}
-		return slice, true
-	}
-
-	return []uint64{}, false
+	return sliceOk(p.GetUint64SliceE(key))
 }
 
 // GetUint64Slice get param by key, return slice of unsigned integers
@@ -538,30 +348,7 @@ func (p *Params) GetString(key string) string {
 
 // GetStringSliceOk get param by key, return slice of strings
 func (p *Params) GetStringSliceOk(key string) ([]string, bool) {
-	val, ok := p.Get(key)
-	if ok {
-		switch v := val.(type) {
-		case []string:
-			return v, true
-		case []byte:
-			return strings.Split(string(v), ","), true
-		case string:
-			return strings.Split(v, ","), true
-		case []interface{}:
-			slice := make([]string, 0, len(v))
-			for _, k := range v {
-				if str, okS := k.(string); okS {
-					slice = append(slice, str)
-				} else {
-					return []string{}, false
-				}
-			}
-			return slice, true
-		default:
-			return []string{}, false
-		}
-	}
-	return []string{}, false
+	return sliceOk(p.GetStringSliceE(key))
 }
 
 // GetStringSlice get param by key, return slice of strings
@@ -570,22 +357,10 @@ func (p *Params) GetStringSlice(key string) []string {
 	return val
 }
 
-// GetBytesOk get param by key, return slice of bytes
+// GetBytesOk get param by key, return slice of bytes (strings are base64 decoded)
 func (p *Params) GetBytesOk(key string) ([]byte, bool) {
-	if dataStr, ok := p.Get(key); ok {
-		var dataByte []byte
-		if dataByte, ok = dataStr.([]byte); !ok {
-			var err error
-			dataByte, err = base64.StdEncoding.DecodeString(dataStr.(string))
-			if err != nil {
-				log.Println("error decoding data:", key, err)
-				return nil, true
-			}
-			p.Values[key] = dataByte
-		}
-		return dataByte, true
-	}
-	return nil, false
+	val, err := p.GetBytesE(key)
+	return val, err == nil
 }
 
 // GetBytes get param by key, return slice of bytes
@@ -596,7 +371,7 @@ func (p *Params) GetBytes(key string) []byte {
 
 // GetTimeOk get param by key, return time
 func (p *Params) GetTimeOk(key string) (time.Time, bool) {
-	return p.GetTimeInLocationOk(key, time.UTC)
+	return p.GetTimeInLocationOk(key, p.Location())
 }
 
 // GetTime get param by key, return time
@@ -607,28 +382,8 @@ func (p *Params) GetTime(key string) time.Time {
 
 // GetTimeInLocationOk get param by key, return time
 func (p *Params) GetTimeInLocationOk(key string, loc *time.Location) (time.Time, bool) {
-	val, ok := p.Get(key)
-	if !ok {
-		return time.Time{}, false
-	}
-	if t, success := val.(time.Time); success {
-		return t, true
-	}
-	if str, success := val.(string); success {
-		if t, err := time.ParseInLocation(time.RFC3339, str, loc); err == nil {
-			return t, true
-		}
-		if t, err := time.ParseInLocation(DateOnly, str, loc); err == nil {
-			return t, true
-		}
-		if t, err := time.ParseInLocation(DateTime, str, loc); err == nil {
-			return t, true
-		}
-		if t, err := time.ParseInLocation(HTMLDateTimeLocal, str, loc); err == nil {
-			return t, true
-		}
-	}
-	return time.Time{}, false // Changed from true to false
+	t, err := p.GetTimeInLocationE(key, loc)
+	return t, err == nil
 }
 
 // GetTimeInLocation get param by key, return time
@@ -637,16 +392,44 @@ func (p *Params) GetTimeInLocation(key string, loc *time.Location) time.Time {
 	return val
 }
 
-// GetFileOk get param by key, return file
+// GetFileOk get param by key, return the (first) file uploaded with the key
 func (p *Params) GetFileOk(key string) (*multipart.FileHeader, bool) {
 	val, ok := p.Get(key)
 	if !ok {
 		return nil, false
 	}
-	if fh, good := val.(*multipart.FileHeader); good {
-		return fh, true
+	switch v := val.(type) {
+	case *multipart.FileHeader:
+		return v, v != nil
+	case []*multipart.FileHeader:
+		if len(v) > 0 && v[0] != nil {
+			return v[0], true
+		}
+	}
+	return nil, false
+}
+
+// GetFilesOk get param by key, return all the files uploaded with the key
+func (p *Params) GetFilesOk(key string) ([]*multipart.FileHeader, bool) {
+	val, ok := p.Get(key)
+	if !ok {
+		return nil, false
 	}
-	return nil, true
+	switch v := val.(type) {
+	case *multipart.FileHeader:
+		if v != nil {
+			return []*multipart.FileHeader{v}, true
+		}
+	case []*multipart.FileHeader:
+		return v, true
+	}
+	return nil, false
+}
+
+// GetFiles get param by key, return all the files uploaded with the key
+func (p *Params) GetFiles(key string) []*multipart.FileHeader {
+	val, _ := p.GetFilesOk(key)
+	return val
 }
 
 // GetJSONOk get param by key, return map of string interface
@@ -673,20 +456,126 @@ func (p *Params) GetJSON(key string) map[string]interface{} {
 	return val
 }
 
-// Clone makes a copy of this params object
+// Clone makes a shallow copy of this params object: the top-level values are copied, nested
+// objects, arrays and []byte values are shared with the original (see DeepClone)
 func (p *Params) Clone() *Params {
+	p.ensureParsed()
 	values := make(map[string]interface{}, len(p.Values))
 	for k, v := range p.Values {
 		values[k] = v
 	}
 	return &Params{
-		isBinary: p.isBinary,
-		Values:   values,
+		isBinary:    p.isBinary,
+		attachments: p.attachments,
+		location:    p.location,
+		Values:      values,
+
+		durationUnit:      p.durationUnit,
+		negativeDurations: p.negativeDurations,
+		epochUnit:         p.epochUnit,
+		epochTimes:        p.epochTimes,
+
+		maxNumberDigits:   p.maxNumberDigits,
+		bigFloatPrecision: p.bigFloatPrecision,
+		decimalLimits:     p.decimalLimits,
 	}
 }
 
+// DeepClone makes a deep copy of this params object: nested objects, arrays and []byte values are
+// copied so changes of the copy never change the original, uploaded files (*multipart.FileHeader)
+// and attachments are shared by design
+func (p *Params) DeepClone() *Params {
+	p.ensureParsed()
+	values, _ := deepCopy(p.Values).(map[string]interface{})
+	return &Params{
+		isBinary:    p.isBinary,
+		attachments: append([]*Attachment(nil), p.attachments...),
+		location:    p.location,
+		Values:      values,
+
+		durationUnit:      p.durationUnit,
+		negativeDurations: p.negativeDurations,
+		epochUnit:         p.epochUnit,
+		epochTimes:        p.epochTimes,
+
+		maxNumberDigits:   p.maxNumberDigits,
+		bigFloatPrecision: p.bigFloatPrecision,
+		decimalLimits:     p.decimalLimits,
+	}
+}
+
+// deepCopy copies the maps, slices and []byte of the value, other values (including pointers) are shared
+func deepCopy(val interface{}) interface{} {
+	switch v := val.(type) {
+	case nil:
+		return nil
+	case map[string]interface{}:
+		if v == nil {
+			return v
+		}
+		copied := make(map[string]interface{}, len(v))
+		for k, elem := range v {
+			copied[k] = deepCopy(elem)
+		}
+		return copied
+	case []interface{}:
+		if v == nil {
+			return v
+		}
+		copied := make([]interface{}, len(v))
+		for i, elem := range v {
+			copied[i] = deepCopy(elem)
+		}
+		return copied
+	case []byte:
+		if v == nil {
+			return v
+		}
+		return append([]byte{}, v...)
+	}
+
+	rv := reflect.ValueOf(val)
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.IsNil() {
+			return val
+		}
+		copied := reflect.MakeMapWithSize(rv.Type(), rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), deepCopyValue(iter.Value()))
+		}
+		return copied.Interface()
+	case reflect.Slice:
+		if rv.IsNil() {
+			return val
+		}
+		copied := reflect.MakeSlice(rv.Type(), rv.Len(), rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			copied.Index(i).Set(deepCopyValue(rv.Index(i)))
+		}
+		return copied.Interface()
+	default:
+		return val
+	}
+}
+
+// deepCopyValue copies a map or slice element of the reflected value
+func deepCopyValue(v reflect.Value) reflect.Value {
+	if !v.IsValid() || (v.Kind() == reflect.Interface && v.IsNil()) {
+		return v
+	}
+	copied := reflect.ValueOf(deepCopy(v.Interface()))
+	if !copied.IsValid() {
+		return reflect.Zero(v.Type())
+	}
+	return copied.Convert(v.Type())
+}
+
 // Imbue sets the parameters to the object by type; does not handle nested parameters
 func (p *Params) Imbue(obj interface{}) {
+	p.ensureParsed()
+
 	// Get the type of the object
 	typeOfObject := reflect.TypeOf(obj).Elem()
 
@@ -711,7 +600,12 @@ func (p *Params) Imbue(obj interface{}) {
 		field := objectValue.FieldByName(key)
 
 		// Check our types and set accordingly
-		if fieldType.Type.Kind() == reflect.String {
+		if format, hasFormat := fieldType.Tag.Lookup(FormatTag); hasFormat && fieldType.Type.Kind() == reflect.String {
+			// Set string normalized by the format (empty if invalid), skip the field if the format is unknown
+			if _, known := lookupFormat(format); known {
+				field.SetString(p.GetFormat(k, format))
+			}
+		} else if fieldType.Type.Kind() == reflect.String {
 			// Set string
 			field.Set(reflect.ValueOf(p.GetString(k)))
 		} else if fieldType.Type.Kind() == reflect.Uint64 {
@@ -725,7 +619,7 @@ func (p *Params) Imbue(obj interface{}) {
 			field.Set(reflect.ValueOf(p.GetBool(k)))
 		} else if fieldType.Type.Kind() == reflect.Float32 {
 			// Set float32
-			field.Set(reflect.ValueOf(float32(p.GetFloat(k))))
+			field.Set(reflect.ValueOf(p.GetFloat32(k)))
 		} else if fieldType.Type.Kind() == reflect.Float64 {
 			// Set float64
 			field.Set(reflect.ValueOf(p.GetFloat(k)))
@@ -754,6 +648,11 @@ func (p *Params) Imbue(obj interface{}) {
 				continue
 			}
 
+			// Use the registered converter for the type (if any)
+			if imbueConverted(p, k, &field) {
+				continue
+			}
+
 			if subValues, ok := p.GetJSONOk(k); ok {
 				fieldValue := reflect.Indirect(objectValue).FieldByName(key)
 				if reflect.ValueOf(fieldValue).IsZero() {
@@ -775,6 +674,7 @@ func (p *Params) Imbue(obj interface{}) {
 
 // HasAll will return if all specified keys are found in the params object
 func (p *Params) HasAll(keys ...string) (bool, []string) {
+	p.ensureParsed()
 	missing := make([]string, 0)
 	for _, key := range keys {
 		if _, exists := p.Values[key]; !exists {
@@ -786,6 +686,7 @@ func (p *Params) HasAll(keys ...string) (bool, []string) {
 
 // Permit permits only the allowed fields given by allowedKeys
 func (p *Params) Permit(allowedKeys []string) {
+	p.ensureParsed()
 	for key := range p.Values {
 		if !contains(allowedKeys, key) {
 			delete(p.Values, key)
@@ -813,37 +714,138 @@ func GetParams(req *http.Request) *Params {
 	return params
 }
 
-// ParseParams parse parameters
+// ParseParams parse parameters, the DefaultLimits are enforced while parsing
 func ParseParams(req *http.Request) *Params {
-	var p Params
 	if params, exists := req.Context().Value(ParamsKeyName).(*Params); exists {
 		return params
 	}
-	ct := req.Header.Get("Content-Type")
-	ct = strings.Split(ct, ";")[0]
+	return parseRequest(req, DefaultLimits)
+}
+
+// ParseParamsWithLimits parse parameters enforcing the given limits, a *LimitError or *UploadError is
+// returned (and the params are left empty apart from route variables) if the request exceeds a limit
+func ParseParamsWithLimits(req *http.Request, limits Limits) (*Params, error) {
+	if params, exists := req.Context().Value(ParamsKeyName).(*Params); exists {
+		return params, params.ParseError()
+	}
+	params := parseRequest(req, limits)
+	return params, params.parseErr
+}
+
+// ParseError returns the error that stopped the request from being parsed, such as a *LimitError or *UploadError
+func (p *Params) ParseError() error {
+	p.ensureParsed()
+	return p.parseErr
+}
+
+// parseRequest reads the query, form, body and route variables of the request
+func parseRequest(req *http.Request, limits Limits) *Params {
+	p, err := decodeRequest(req, limits)
+	if err != nil {
+		log.Println("failed parsing request:", err)
+		p = &Params{
+			parseErr: err,
+			Values:   make(map[string]interface{}),
+		}
+	}
+
+	for k, v := range mux.Vars(req) {
+		p.Values[k] = pathValue(k, v)
+	}
+
+	return p
+}
+
+// formValues converts the form to values: the first value of each key, "true" and "false" as booleans
+func formValues(form url.Values) map[string]interface{} {
+	values := make(map[string]interface{}, len(form))
+	for k, v := range form {
+		if strings.ToLower(v[0]) == "true" {
+			values[k] = true
+		} else if strings.ToLower(v[0]) == "false" {
+			values[k] = false
+		} else {
+			values[k] = v[0]
+		}
+	}
+	return values
+}
+
+// decodeRequest decodes the query, form and body of the request, only limit and upload errors are returned
+func decodeRequest(req *http.Request, limits Limits) (_ *Params, err error) {
+	var p Params
+	limits.limitBody(req)
+	counter := &limitCounter{limits: limits}
+	ct, ctParams, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		ct = strings.Split(req.Header.Get("Content-Type"), ";")[0]
+	}
+	var partTypes map[string]string
 	if ct == "multipart/form-data" {
-		if err := req.ParseMultipartForm(10000000); err != nil { //nolint:gosec // form size is bounded to 10MB by the explicit maxMemory argument
+		// The temporary files of the form are removed when the request is rejected
+		defer func() {
+			if err != nil && req.MultipartForm != nil {
+				_ = req.MultipartForm.RemoveAll()
+			}
+		}()
+
+		// The parts are checked while the form is parsed, a policy violation stops the parser
+		scanner := scanMultipart(req, limits.Uploads)
+		err = req.ParseMultipartForm(multipartMaxMemory)
+		if scanner != nil {
+			var scanErr error
+			if partTypes, scanErr = scanner.wait(); scanErr != nil {
+				return nil, scanErr
+			}
+		}
+		if err != nil {
+			if limitErr := limits.bodyError(err); limitErr != nil {
+				return nil, limitErr
+			}
 			log.Println("Request.ParseMultipartForm error:", err)
 		}
 	} else {
 		if err := req.ParseForm(); err != nil {
+			if limitErr := limits.bodyError(err); limitErr != nil {
+				return nil, limitErr
+			}
 			log.Println("request.ParseForm error:", err)
 		}
 	}
-	tempMap := make(map[string]interface{}, len(req.Form))
-	for k, v := range req.Form {
-		if strings.ToLower(v[0]) == "true" {
-			tempMap[k] = true
-		} else if strings.ToLower(v[0]) == "false" {
-			tempMap[k] = false
-		} else {
-			tempMap[k] = v[0]
+	if err := counter.checkForm(req.Form, partTypes); err != nil {
+		return nil, err
+	}
+	if req.MultipartForm != nil {
+		if err := counter.addKeys(len(req.MultipartForm.File)); err != nil {
+			return nil, err
 		}
 	}
+	tempMap := formValues(req.Form)
+
+	// Decode the json and msgpack parts of a multipart form into nested values
+	for k, mediaType := range partTypes {
+		raw, ok := tempMap[k].(string)
+		if !ok {
+			continue
+		}
+		decoded, err := counter.decodePart(raw, mediaType)
+		if err != nil {
+			var limitErr *LimitError
+			if errors.As(err, &limitErr) {
+				return nil, err
+			}
+			log.Println("failed decoding multipart part:", k, err)
+			continue
+		}
+		tempMap[k] = decoded
+	}
 
 	if req.MultipartForm != nil {
 		for k, v := range req.MultipartForm.File {
-			tempMap[k] = v[0]
+			// The files are always kept as a slice, even a single file
+			if len(v) > 0 {
+				tempMap[k] = v
+			}
 		}
 	}
 
@@ -855,10 +857,20 @@ func ParseParams(req *http.Request) *Params {
 			// no errors, restore the body on the request for other readers
 			req.Body = io.NopCloser(bytes.NewReader(body))
 		}
+	} else if limitErr := limits.bodyError(err); limitErr != nil {
+		return nil, limitErr
 	}
 
 	if ct == "application/json" && req.ContentLength > 0 {
-		err = json.Unmarshal(body, &p.Values)
+		if limits.structural() {
+			p.Values, err = counter.decodeJSON(bytes.NewReader(body))
+			var limitErr *LimitError
+			if errors.As(err, &limitErr) {
+				return nil, err
+			}
+		} else {
+			err = unmarshalJSON(body, &p.Values)
+		}
 		if err != nil {
 			log.Println("content-type is \"application/json\" but no valid json data received:", err)
 			p.Values = tempMap
@@ -868,10 +880,24 @@ func ParseParams(req *http.Request) *Params {
 				p.Values[k] = v
 			}
 		}
+	} else if ct == mediaTypeMultipartRelated || ct == mediaTypeMultipartMixed {
+		if p.Values, p.attachments, err = counter.decodeRelated(body, ctParams); err != nil {
+			return nil, err
+		}
+		for k, v := range tempMap {
+			if _, pres := p.Values[k]; !pres {
+				p.Values[k] = v
+			}
+		}
 	} else if ct == "application/x-msgpack" {
 		var mh codec.MsgpackHandle
 		p.isBinary = true
 		mh.MapType = reflect.TypeOf(p.Values)
+		if limits.structural() {
+			if err = counter.scanMsgpack(body); err != nil {
+				return nil, err
+			}
+		}
 		if len(body) > 0 {
 			buff := bytes.NewBuffer(body)
 			first := body[0]
@@ -926,22 +952,18 @@ func ParseParams(req *http.Request) *Params {
 		p.Values = tempMap
 	}
 
-	for k, v := range mux.Vars(req) {
-		const keyID = "id"
-		if strings.Contains(k, keyID) {
-			var id uint64
-			id, err = strconv.ParseUint(v, 10, 64)
-			if err != nil {
-				p.Values[k] = v
-			} else {
-				p.Values[k] = id
-			}
-		} else {
-			p.Values[k] = v
+	return &p, nil
+}
+
+// pathValue converts a route variable, keys containing "id" become uint64 when possible
+func pathValue(key, value string) interface{} {
+	const keyID = "id"
+	if strings.Contains(key, keyID) {
+		if id, err := strconv.ParseUint(value, 10, 64); err == nil {
+			return id
 		}
 	}
-
-	return &p
+	return value
 }
 
 // MakeParsedReq make parsed request
@@ -958,17 +980,7 @@ func MakeHTTPRouterParsedReq(fn httprouter.Handle) httprouter.Handle {
 		r = r.WithContext(context.WithValue(r.Context(), ParamsKeyName, ParseParams(r)))
 		params := GetParams(r)
 		for _, param := range p {
-			const keyID = "id"
-			if strings.Contains(param.Key, keyID) {
-				id, err := strconv.ParseUint(param.Value, 10, 64)
-				if err != nil {
-					params.Values[param.Key] = param.Value
-				} else {
-					params.Values[param.Key] = id
-				}
-			} else {
-				params.Values[param.Key] = param.Value
-			}
+			params.Values[param.Key] = pathValue(param.Key, param.Value)
 		}
 		fn(rw, r, p)
 	}
@@ -2,9 +2,11 @@ package parameters
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -100,23 +102,24 @@ func TestGetParams_ParseNestedJSONBody(t *testing.T) {
 
 	coordinate := val.(map[string]interface{})
 
+	// Json numbers are kept as json.Number
 	var lat interface{}
 	lat, present = coordinate["lat"]
 	assert.True(t, present)
-	assert.InEpsilon(t, 50.505, lat, 0.0001)
+	assert.Equal(t, json.Number("50.505"), lat)
 
-	lat, present = params.Get("coordinate.lat")
+	latitude, present := params.GetFloatOk("coordinate.lat")
 	assert.True(t, present)
-	assert.InEpsilon(t, 50.505, lat, 0.0001)
+	assert.InEpsilon(t, 50.505, latitude, 0.0001)
 
 	var lon interface{}
 	lon, present = coordinate["lon"]
 	assert.True(t, present)
-	assert.InEpsilon(t, 10.101, lon, 0.0001)
+	assert.Equal(t, json.Number("10.101"), lon)
 
-	lon, present = params.Get("coordinate.lon")
+	longitude, present := params.GetFloatOk("coordinate.lon")
 	assert.True(t, present)
-	assert.InEpsilon(t, 10.101, lon, 0.0001)
+	assert.InEpsilon(t, 10.101, longitude, 0.0001)
 }
 
 // TestGetParams tests the GetParams method
@@ -234,6 +237,28 @@ func TestParams_GetBytesOk(t *testing.T) {
 	assert.Len(t, val, 75)
 }
 
+// TestParams_GetBytesOkConversion tests that GetBytesOk converts like GetBytesE and does not change the values
+func TestParams_GetBytesOkConversion(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"number":  1.5,
+		"bool":    true,
+		"invalid": "not base64!",
+		"nested":  map[string]interface{}{"data": "aGVsbG8="},
+	}}
+
+	for _, key := range []string{"number", "bool", "invalid", "missing"} {
+		val, ok := params.GetBytesOk(key)
+		assert.False(t, ok, key)
+		assert.Nil(t, val, key)
+	}
+
+	val, ok := params.GetBytesOk("nested.data")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("hello"), val)
+	assert.NotContains(t, params.Values, "nested.data")
+	assert.Len(t, params.Values, 4)
+}
+
 // BenchmarkParams_GetBytesOk benchmarks the method
 func BenchmarkParams_GetBytesOk(b *testing.B) {
 	testBytes := make([]byte, 100)
@@ -315,7 +340,10 @@ func TestParams_GetFloatOk_Zero(t *testing.T) {
 
 	val, ok := params.GetFloatOk("test")
 	assert.InDelta(t, float64(0), val, 0.0001)
-	assert.True(t, ok)
+	assert.False(t, ok)
+
+	_, ok = params.GetFloatOk("missing")
+	assert.False(t, ok)
 }
 
 // TestParams_GetIntOk tests the GetIntOk method
@@ -334,6 +362,18 @@ func TestParams_GetIntOk(t *testing.T) {
 
 	val = params.GetInt("test")
 	assert.Equal(t, 123, val)
+
+	// Go number syntax and exponents are not integers
+	for _, input := range []string{"1_000", "0x1p4", "1e3", "0x10", "0b1", "Inf", "NaN"} {
+		params = &Params{Values: map[string]interface{}{"test": input}}
+		val, ok = params.GetIntOk("test")
+		assert.False(t, ok, input)
+		assert.Equal(t, 0, val, input)
+		_, ok = params.GetInt64Ok("test")
+		assert.False(t, ok, input)
+		_, ok = params.GetUint8Ok("test")
+		assert.False(t, ok, input)
+	}
 }
 
 // BenchmarkParams_GetIntOk benchmarks the method
@@ -1283,8 +1323,8 @@ func TestParams_GetIntSliceOk(t *testing.T) {
 				},
 			},
 			key:            testIntegersParam,
-			expectedSlice:  nil,
-			expectedResult: true,
+			expectedSlice:  []int{},
+			expectedResult: false,
 		},
 		{
 			name: "Value is empty string",
@@ -1294,7 +1334,7 @@ func TestParams_GetIntSliceOk(t *testing.T) {
 				},
 			},
 			key:            testIntegersParam,
-			expectedSlice:  nil,
+			expectedSlice:  []int{},
 			expectedResult: true,
 		},
 		{
@@ -1305,8 +1345,8 @@ func TestParams_GetIntSliceOk(t *testing.T) {
 				},
 			},
 			key:            testIntegersParam,
-			expectedSlice:  nil,
-			expectedResult: true,
+			expectedSlice:  []int{},
+			expectedResult: false,
 		},
 		{
 			name: "Value contains invalid data in string",
@@ -1316,7 +1356,7 @@ func TestParams_GetIntSliceOk(t *testing.T) {
 				},
 			},
 			key:            testIntegersParam,
-			expectedSlice:  []int{19},
+			expectedSlice:  []int{},
 			expectedResult: false,
 		},
 		{
@@ -1327,9 +1367,31 @@ func TestParams_GetIntSliceOk(t *testing.T) {
 				},
 			},
 			key:            testIntegersParam,
-			expectedSlice:  []int{21},
+			expectedSlice:  []int{},
+			expectedResult: false,
+		},
+		{
+			name: "Value is []interface{} with non-integer float64",
+			params: &Params{
+				Values: map[string]interface{}{
+					testIntegersParam: []interface{}{1.0, 2.5},
+				},
+			},
+			key:            testIntegersParam,
+			expectedSlice:  []int{},
 			expectedResult: false,
 		},
+		{
+			name: "Value is comma-separated string with spaces",
+			params: &Params{
+				Values: map[string]interface{}{
+					testIntegersParam: " 1, 2 ,3",
+				},
+			},
+			key:            testIntegersParam,
+			expectedSlice:  []int{1, 2, 3},
+			expectedResult: true,
+		},
 		{
 			name: testKeyNotFound,
 			params: &Params{
@@ -2111,7 +2173,7 @@ func TestParams_GetStringSliceOk(t *testing.T) {
 				},
 			},
 			key:           testKeyParam,
-			expectedSlice: []string{""},
+			expectedSlice: []string{},
 			expectedOk:    true,
 		},
 		{
@@ -2133,7 +2195,7 @@ func TestParams_GetStringSliceOk(t *testing.T) {
 				},
 			},
 			key:           testKeyParam,
-			expectedSlice: []string{""},
+			expectedSlice: []string{},
 			expectedOk:    true,
 		},
 		{
@@ -2255,6 +2317,17 @@ func TestParams_GetTimeInLocationOk(t *testing.T) {
 			expectedTime: time.Time{},
 			expectedOk:   false, // According to the function's behavior
 		},
+		{
+			name: "Value is of unexpected type (bool)",
+			params: &Params{
+				Values: map[string]interface{}{
+					testTimeKeyParam: true,
+				},
+			},
+			key:          testTimeKeyParam,
+			expectedTime: time.Time{},
+			expectedOk:   false,
+		},
 		{
 			name: "Value is nil",
 			params: &Params{
@@ -2365,6 +2438,16 @@ func TestParams_GetTimeInLocation(t *testing.T) {
 			key:          testTimeKeyParam,
 			expectedTime: time.Time{},
 		},
+		{
+			name: "Value is of unexpected type (bool)",
+			params: &Params{
+				Values: map[string]interface{}{
+					testTimeKeyParam: true,
+				},
+			},
+			key:          testTimeKeyParam,
+			expectedTime: time.Time{},
+		},
 		{
 			name: "Value is nil",
 			params: &Params{
@@ -3128,3 +3211,117 @@ func TestMakeHTTPRouterParsedReq(t *testing.T) {
 		})
 	}
 }
+
+// TestParams_GetFileOk tests the GetFileOk method
+func TestParams_GetFileOk(t *testing.T) {
+	req := newMultipartRequest(t, map[string]string{testNameParam: "Alice"},
+		testFile{field: "avatar", filename: "avatar.png", content: testPNG},
+		testFile{field: "photos", filename: "1.png", content: testPNG},
+		testFile{field: "photos", filename: "2.png", content: testPNG},
+	)
+	params := ParseParams(req)
+
+	fh, ok := params.GetFileOk("avatar")
+	assert.True(t, ok)
+	require.NotNil(t, fh)
+	assert.Equal(t, "avatar.png", fh.Filename)
+
+	fh, ok = params.GetFileOk("photos")
+	assert.True(t, ok)
+	require.NotNil(t, fh)
+	assert.Equal(t, "1.png", fh.Filename)
+
+	fh, ok = params.GetFileOk(testNameParam)
+	assert.False(t, ok)
+	assert.Nil(t, fh)
+
+	fh, ok = params.GetFileOk("missing")
+	assert.False(t, ok)
+	assert.Nil(t, fh)
+}
+
+// TestParams_GetFilesOk tests the GetFilesOk method
+func TestParams_GetFilesOk(t *testing.T) {
+	req := newMultipartRequest(t, map[string]string{testNameParam: "Alice"},
+		testFile{field: "avatar", filename: "avatar.png", content: testPNG},
+		testFile{field: "photos", filename: "1.png", content: testPNG},
+		testFile{field: "photos", filename: "2.png", content: testPNG},
+		testFile{field: "photos", filename: "3.png", content: testPNG},
+	)
+	params := ParseParams(req)
+
+	files, ok := params.GetFilesOk("photos")
+	assert.True(t, ok)
+	require.Len(t, files, 3)
+	for i, fh := range files {
+		assert.Equal(t, strconv.Itoa(i+1)+".png", fh.Filename)
+	}
+
+	files, ok = params.GetFilesOk("avatar")
+	assert.True(t, ok)
+	require.Len(t, files, 1)
+	assert.Equal(t, "avatar.png", files[0].Filename)
+	assert.IsType(t, []*multipart.FileHeader{}, params.Values["avatar"])
+
+	avatar, ok := params.GetFileOk("avatar")
+	assert.True(t, ok)
+	assert.Same(t, files[0], avatar)
+
+	files, ok = params.GetFilesOk(testNameParam)
+	assert.False(t, ok)
+	assert.Nil(t, files)
+
+	assert.Nil(t, params.GetFiles("missing"))
+	assert.Len(t, params.GetFiles("photos"), 3)
+}
+
+// TestParams_Clone tests the shallow and deep copies
+func TestParams_Clone(t *testing.T) {
+	file := &multipart.FileHeader{Filename: "avatar.png"}
+	newParams := func() *Params {
+		return &Params{Values: map[string]interface{}{
+			"name":    "John",
+			"billing": map[string]interface{}{"zip": "10115"},
+			"items":   []interface{}{map[string]interface{}{"sku": "A-1"}},
+			"codes":   []string{"x", "y"},
+			"meta":    map[interface{}]interface{}{"source": "msgpack"},
+			"data":    []byte("abc"),
+			"avatar":  file,
+			"null":    nil,
+		}}
+	}
+
+	t.Run("shallow", func(t *testing.T) {
+		params := newParams()
+		clone := params.Clone()
+		clone.Values["name"] = "Jane"
+		require.NoError(t, clone.Set("billing.zip", "10117"))
+
+		assert.Equal(t, "John", params.GetString("name"))
+		assert.Equal(t, "10117", params.GetString("billing.zip"))
+	})
+
+	t.Run("deep", func(t *testing.T) {
+		params := newParams()
+		clone := params.DeepClone()
+		assert.Equal(t, params.Values, clone.Values)
+
+		require.NoError(t, clone.Set("billing.zip", "10117"))
+		require.NoError(t, clone.Set("items.0.sku", "B-2"))
+		require.NoError(t, clone.Set("meta.source", "json"))
+		clone.GetStringSlice("codes")[0] = "z"
+		clone.Values["data"].([]byte)[0] = 'x'
+
+		assert.Equal(t, newParams().Values, params.Values)
+		assert.Same(t, file, clone.Values["avatar"])
+	})
+
+	t.Run("settings", func(t *testing.T) {
+		params := newParams()
+		params.SetLocation(time.FixedZone("test", 3600))
+		params.isBinary = true
+		clone := params.DeepClone()
+		assert.Equal(t, params.Location(), clone.Location())
+		assert.True(t, clone.isBinary)
+	})
+}
@@ -0,0 +1,110 @@
+package parameters
+
+import (
+	"strconv"
+	"strings"
+)
+
+// pathEscaper escapes the characters with a meaning in paths and patterns
+var pathEscaper = strings.NewReplacer(`\`, `\\`, `.`, `\.`, `[`, `\[`, `]`, `\]`, `*`, `\*`)
+
+// EscapeKey escapes the dots, brackets, asterisks and backslashes of a key for use in a path or
+// pattern ("v1.2" becomes "v1\.2", "*" becomes "\*")
+func EscapeKey(key string) string {
+	return pathEscaper.Replace(key)
+}
+
+// pathSegment is a key of a path, an escaped key ("\*") is never a wildcard of a pattern
+type pathSegment struct {
+	key     string
+	escaped bool
+}
+
+// parsePath splits the path into its keys: "items.0.sku", "items[0].sku" and "items[0][sku]" are
+// all "items", "0" and "sku", a backslash escapes the next character ("version\.major" is one key)
+func parsePath(path string) []string {
+	segments := parseSegments(path)
+	keys := make([]string, len(segments))
+	for i, segment := range segments {
+		keys[i] = segment.key
+	}
+	return keys
+}
+
+// parseSegments splits the path into its keys like parsePath, marking the keys with an escaped character
+func parseSegments(path string) []pathSegment {
+	var segments []pathSegment
+	var segment strings.Builder
+	open, escaped := true, false
+	flush := func() {
+		segments = append(segments, pathSegment{key: segment.String(), escaped: escaped})
+		segment.Reset()
+		escaped = false
+	}
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case c == '\\' && i+1 < len(path):
+			i++
+			segment.WriteByte(path[i])
+			open, escaped = true, true
+		case c == '.':
+			if open {
+				flush()
+			}
+			open = true
+		case c == '[' && strings.IndexByte(path[i:], ']') > 0:
+			if segment.Len() > 0 {
+				flush()
+			}
+			end := i + strings.IndexByte(path[i:], ']')
+			segments = append(segments, pathSegment{key: path[i+1 : end]})
+			i = end
+			open = false
+		default:
+			segment.WriteByte(c)
+			open = true
+		}
+	}
+	if open {
+		flush()
+	}
+	return segments
+}
+
+// lookup finds the value of the path in the root map, a key that exists as is (e.g. the form key
+// "billing[zip]") is found first, any type mismatch along the path is not found
+func lookup(root map[string]interface{}, path string) (interface{}, bool) {
+	if val, ok := root[path]; ok {
+		return val, true
+	}
+	var val interface{} = root
+	for _, key := range parsePath(path) {
+		var ok bool
+		if val, ok = child(val, key); !ok {
+			return nil, false
+		}
+	}
+	return val, true
+}
+
+// child returns the value of the key of an object or the element of the index of an array
+func child(val interface{}, key string) (interface{}, bool) {
+	if obj, ok := asObject(val); ok {
+		v, found := obj[key]
+		return v, found
+	}
+	switch val.(type) {
+	case string, []byte:
+		return nil, false
+	}
+	elems, ok := sliceValues(val)
+	if !ok {
+		return nil, false
+	}
+	i, err := strconv.Atoi(key)
+	if err != nil || i < 0 || i >= len(elems) {
+		return nil, false
+	}
+	return elems[i], true
+}
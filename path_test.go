@@ -0,0 +1,94 @@
+package parameters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParsePath tests splitting paths into keys
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{"name", []string{"name"}},
+		{"items.0.sku", []string{"items", "0", "sku"}},
+		{"items[0].sku", []string{"items", "0", "sku"}},
+		{"items[0][sku]", []string{"items", "0", "sku"}},
+		{"[0].sku", []string{"0", "sku"}},
+		{`version\.major`, []string{"version.major"}},
+		{"config[a.b].c", []string{"config", "a.b", "c"}},
+		{`a\\.b`, []string{`a\`, "b"}},
+		{`a\[0]`, []string{"a[0]"}},
+		{"a[0", []string{"a[0"}},
+		{"a..b", []string{"a", "", "b"}},
+		{"", []string{""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parsePath(tt.path))
+		})
+	}
+}
+
+// TestEscapeKey tests that escaped keys are parsed as a single key
+func TestEscapeKey(t *testing.T) {
+	for _, key := range []string{"v1.2", "a[0]", `back\slash`, "plain", "*", "**", "a*b"} {
+		assert.Equal(t, []string{"prefix", key}, parsePath("prefix."+EscapeKey(key)), key)
+	}
+}
+
+// TestParams_GetPath tests getting values by path
+func TestParams_GetPath(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"sku": "A-1", "tags": []interface{}{"new", "sale"}},
+			map[interface{}]interface{}{"sku": "B-2"},
+		},
+		"codes":         []string{"x", "y"},
+		"version.major": 2,
+		"config":        map[string]interface{}{"a.b": true},
+		"name":          "John",
+		"billing[zip]":  "10115",
+		"nothing":       nil,
+	}}
+
+	tests := []struct {
+		path     string
+		expected interface{}
+		found    bool
+	}{
+		{"items.0.sku", "A-1", true},
+		{"items[1].sku", "B-2", true},
+		{"items[0][tags][1]", "sale", true},
+		{"codes.1", "y", true},
+		{"version.major", 2, true},
+		{`version\.major`, 2, true},
+		{`config.a\.b`, true, true},
+		{"config[a.b]", true, true},
+		{"billing[zip]", "10115", true},
+		{"nothing", nil, true},
+		{"items.2.sku", nil, false},
+		{"items.-1.sku", nil, false},
+		{"items.first.sku", nil, false},
+		{"name.first", nil, false},
+		{"name.0", nil, false},
+		{"nothing.x", nil, false},
+		{"items.0.sku.x", nil, false},
+		{"missing.name", nil, false},
+		{"config.a.b", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.NotPanics(t, func() {
+				val, ok := params.Get(tt.path)
+				assert.Equal(t, tt.found, ok)
+				assert.Equal(t, tt.expected, val)
+			})
+		})
+	}
+
+	assert.Equal(t, "A-1", params.GetString("items[0].sku"))
+	assert.Equal(t, 0, params.GetInt("name.length"))
+}
@@ -0,0 +1,160 @@
+package parameters
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The wildcards of GetAll patterns
+const (
+	// WildcardKey matches any key of an object or any index of an array ("items.*.sku")
+	WildcardKey = "*"
+
+	// WildcardPath matches any number of keys and indexes, including none ("**.id")
+	WildcardPath = "**"
+)
+
+// PathValue is a value found by GetAll with its concrete path (e.g. "items.2.sku")
+type PathValue struct {
+	Path  string
+	Value interface{}
+}
+
+// children returns the keys (sorted) and values of an object, or the indexes and elements of an array
+func children(val interface{}) ([]string, []interface{}) {
+	if obj, ok := asObject(val); ok {
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		values := make([]interface{}, len(keys))
+		for i, k := range keys {
+			values[i] = obj[k]
+		}
+		return keys, values
+	}
+	switch val.(type) {
+	case string, []byte:
+		return nil, nil
+	}
+	values, ok := sliceValues(val)
+	if !ok {
+		return nil, nil
+	}
+	keys := make([]string, len(values))
+	for i := range values {
+		keys[i] = strconv.Itoa(i)
+	}
+	return keys, values
+}
+
+// matcher collects the values matching a pattern
+type matcher struct {
+	seen    map[string]bool
+	matches []PathValue
+}
+
+// match walks the value, path is the escaped path of the value
+func (m *matcher) match(val interface{}, path []string, pattern []pathSegment) {
+	if len(pattern) == 0 {
+		concrete := strings.Join(path, ".")
+		if !m.seen[concrete] {
+			m.seen[concrete] = true
+			m.matches = append(m.matches, PathValue{Path: concrete, Value: val})
+		}
+		return
+	}
+
+	wildcard := ""
+	if !pattern[0].escaped {
+		wildcard = pattern[0].key
+	}
+	switch wildcard {
+	case WildcardPath:
+		m.match(val, path, pattern[1:])
+		keys, values := children(val)
+		for i, k := range keys {
+			m.match(values[i], append(path[:len(path):len(path)], EscapeKey(k)), pattern)
+		}
+	case WildcardKey:
+		keys, values := children(val)
+		for i, k := range keys {
+			m.match(values[i], append(path[:len(path):len(path)], EscapeKey(k)), pattern[1:])
+		}
+	default:
+		if v, ok := child(val, pattern[0].key); ok {
+			m.match(v, append(path[:len(path):len(path)], EscapeKey(pattern[0].key)), pattern[1:])
+		}
+	}
+}
+
+// GetAll returns every value matching the pattern with its concrete path, "*" matches any key or
+// index and "**" any number of them (escaped with EscapeKey they match the key, "\*" is the key "*"),
+// values are returned before the values nested in them, objects in key order and arrays in index order
+//
+//	for _, sku := range params.GetAll("items.*.sku") {
+//		log.Println(sku.Path, sku.Value) // items.0.sku A-1
+//	}
+func (p *Params) GetAll(pattern string) []PathValue {
+	p.ensureParsed()
+	m := &matcher{seen: make(map[string]bool)}
+	m.match(p.Values, nil, parseSegments(pattern))
+	return m.matches
+}
+
+// getAll converts every value matching the pattern, the errors have the concrete path as key
+func getAll[T any](p *Params, pattern, target string, convert func(key string, val interface{}) (T, error)) ([]T, error) {
+	matches := p.GetAll(pattern)
+	values := make([]T, 0, len(matches))
+	for _, match := range matches {
+		if match.Value == nil {
+			return nil, newConversionError(match.Path, nil, target, ErrNullValue)
+		}
+		converted, err := convert(match.Path, match.Value)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, converted)
+	}
+	return values, nil
+}
+
+// GetAllStringsE returns every value matching the pattern as string or a *ConversionError
+func (p *Params) GetAllStringsE(pattern string) ([]string, error) {
+	return getAll(p, pattern, "string", func(key string, val interface{}) (string, error) {
+		return convertString(key, val, "string")
+	})
+}
+
+// GetAllStringsOk returns every value matching the pattern as string
+func (p *Params) GetAllStringsOk(pattern string) ([]string, bool) {
+	return sliceOk(p.GetAllStringsE(pattern))
+}
+
+// GetAllStrings returns every value matching the pattern as string
+func (p *Params) GetAllStrings(pattern string) []string {
+	val, _ := p.GetAllStringsOk(pattern)
+	return val
+}
+
+// GetAllIntsE returns every value matching the pattern as integer or a *ConversionError
+func (p *Params) GetAllIntsE(pattern string) ([]int, error) {
+	return getAll(p, pattern, "int", func(key string, val interface{}) (int, error) {
+		i, err := convertSigned(key, val, "int", math.MinInt, math.MaxInt)
+		return int(i), err
+	})
+}
+
+// GetAllIntsOk returns every value matching the pattern as integer
+func (p *Params) GetAllIntsOk(pattern string) ([]int, bool) {
+	return sliceOk(p.GetAllIntsE(pattern))
+}
+
+// GetAllInts returns every value matching the pattern as integer
+func (p *Params) GetAllInts(pattern string) []int {
+	val, _ := p.GetAllIntsOk(pattern)
+	return val
+}
@@ -0,0 +1,128 @@
+package parameters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newQueryParams returns the params used by the query tests
+func newQueryParams() *Params {
+	return &Params{Values: map[string]interface{}{
+		"id": 1,
+		"items": []interface{}{
+			map[string]interface{}{"id": 10, "sku": "A-1", "quantity": "2"},
+			map[string]interface{}{"id": 11, "sku": "B-2", "quantity": 1.0},
+			map[string]interface{}{"id": 12, "quantity": "many"},
+		},
+		"customer": map[string]interface{}{
+			"id":      "100",
+			"address": map[string]interface{}{"id": 1000, "city": "Berlin"},
+		},
+		"meta": map[string]interface{}{"v1.2": map[string]interface{}{"id": 7}},
+		"note": nil,
+	}}
+}
+
+// TestParams_GetAll tests the wildcard patterns
+func TestParams_GetAll(t *testing.T) {
+	params := newQueryParams()
+
+	tests := []struct {
+		pattern  string
+		expected []PathValue
+	}{
+		{"items.*.sku", []PathValue{{"items.0.sku", "A-1"}, {"items.1.sku", "B-2"}}},
+		{"items[*].id", []PathValue{{"items.0.id", 10}, {"items.1.id", 11}, {"items.2.id", 12}}},
+		{"customer.*", []PathValue{
+			{"customer.address", map[string]interface{}{"id": 1000, "city": "Berlin"}},
+			{"customer.id", "100"},
+		}},
+		{"**.id", []PathValue{
+			{"id", 1},
+			{"customer.id", "100"},
+			{"customer.address.id", 1000},
+			{"items.0.id", 10},
+			{"items.1.id", 11},
+			{"items.2.id", 12},
+			{`meta.v1\.2.id`, 7},
+		}},
+		{"customer.**.city", []PathValue{{"customer.address.city", "Berlin"}}},
+		{"**.**.city", []PathValue{{"customer.address.city", "Berlin"}}},
+		{"items.1", []PathValue{{"items.1", map[string]interface{}{"id": 11, "sku": "B-2", "quantity": 1.0}}}},
+		{"items.*.missing", nil},
+		{"id.*", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			matches := params.GetAll(tt.pattern)
+			assert.Equal(t, tt.expected, matches)
+
+			// The concrete paths lead to the values
+			for _, match := range matches {
+				val, ok := params.Get(match.Path)
+				assert.True(t, ok, match.Path)
+				assert.Equal(t, match.Value, val)
+			}
+		})
+	}
+}
+
+// TestParams_GetAllEscaped tests that escaped wildcards match the keys "*" and "**"
+func TestParams_GetAllEscaped(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"*":     1,
+		"**":    map[string]interface{}{"a*b": 2},
+		"other": 3,
+	}}
+
+	tests := []struct {
+		pattern  string
+		expected []PathValue
+	}{
+		{EscapeKey("*"), []PathValue{{`\*`, 1}}},
+		{EscapeKey("**") + "." + EscapeKey("a*b"), []PathValue{{`\*\*.a\*b`, 2}}},
+		{EscapeKey("**") + ".*", []PathValue{{`\*\*.a\*b`, 2}}},
+		{"*", []PathValue{
+			{`\*`, 1},
+			{`\*\*`, map[string]interface{}{"a*b": 2}},
+			{"other", 3},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			matches := params.GetAll(tt.pattern)
+			assert.Equal(t, tt.expected, matches)
+			for _, match := range matches {
+				val, ok := params.Get(match.Path)
+				assert.True(t, ok, match.Path)
+				assert.Equal(t, match.Value, val)
+			}
+		})
+	}
+}
+
+// TestParams_GetAllTyped tests the typed wildcard getters
+func TestParams_GetAllTyped(t *testing.T) {
+	params := newQueryParams()
+
+	assert.Equal(t, []string{"A-1", "B-2"}, params.GetAllStrings("items.*.sku"))
+	assert.Equal(t, []int{1, 100, 1000, 10, 11, 12, 7}, params.GetAllInts("**.id"))
+	assert.Empty(t, params.GetAllInts("items.*.missing"))
+
+	_, err := params.GetAllIntsE("items.*.quantity")
+	var convErr *ConversionError
+	require.ErrorAs(t, err, &convErr)
+	assert.Equal(t, "items.2.quantity", convErr.Key)
+	assert.Equal(t, "items.2.quantity must be an integer", err.Error())
+
+	_, ok := params.GetAllIntsOk("items.*.quantity")
+	assert.False(t, ok)
+
+	_, err = params.GetAllStringsE("**.id")
+	require.ErrorIs(t, err, ErrWrongType)
+
+	_, err = params.GetAllStringsE("note")
+	require.ErrorIs(t, err, ErrNullValue)
+}
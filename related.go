@@ -0,0 +1,250 @@
+package parameters
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"strings"
+)
+
+// Media types of multipart requests with a root part and attachments
+const (
+	mediaTypeMultipartRelated = "multipart/related"
+	mediaTypeMultipartMixed   = "multipart/mixed"
+	mediaTypeFormURLEncoded   = "application/x-www-form-urlencoded"
+)
+
+// Attachment is a part of a multipart/related or multipart/mixed request, other than the root part
+type Attachment struct {
+	// ContentID is the Content-ID of the part without the angle brackets
+	ContentID string
+
+	// ContentType is the Content-Type of the part
+	ContentType string
+
+	// Filename is the file name of the part (if any)
+	Filename string
+
+	// Header is the MIME header of the part
+	Header textproto.MIMEHeader
+
+	// Size is the size of the (decoded) content in bytes
+	Size int64
+
+	content []byte
+}
+
+// attachmentFile is the file returned by Attachment.Open
+type attachmentFile struct {
+	*bytes.Reader
+}
+
+// Close will close the file
+func (attachmentFile) Close() error {
+	return nil
+}
+
+// Open opens the content of the attachment
+func (a *Attachment) Open() (multipart.File, error) {
+	return attachmentFile{Reader: bytes.NewReader(a.content)}, nil
+}
+
+// Attachments returns the attachments of a multipart/related or multipart/mixed request in order
+func (p *Params) Attachments() []*Attachment {
+	p.ensureParsed()
+	return p.attachments
+}
+
+// GetAttachmentOk get attachment by Content-ID, with or without angle brackets or a "cid:" prefix
+func (p *Params) GetAttachmentOk(contentID string) (*Attachment, bool) {
+	contentID = normalizeContentID(strings.TrimPrefix(contentID, "cid:"))
+	if contentID == "" {
+		return nil, false
+	}
+	for _, a := range p.Attachments() {
+		if a.ContentID == contentID {
+			return a, true
+		}
+	}
+	return nil, false
+}
+
+// GetAttachment get attachment by Content-ID
+func (p *Params) GetAttachment(contentID string) *Attachment {
+	val, _ := p.GetAttachmentOk(contentID)
+	return val
+}
+
+// normalizeContentID removes the angle brackets around a Content-ID
+func normalizeContentID(contentID string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(contentID), "<"), ">")
+}
+
+// decodeRelated decodes a multipart/related or multipart/mixed body, the root part (given by the "start"
+// parameter or the first part) is decoded into the values and the other parts become attachments.
+// Every attachment counts as a key and the upload policy (if any) applies to the attachments.
+func (c *limitCounter) decodeRelated(body []byte, ctParams map[string]string) (map[string]interface{}, []*Attachment, error) {
+	values := make(map[string]interface{})
+	if ctParams["boundary"] == "" {
+		return values, nil, nil
+	}
+
+	start := normalizeContentID(ctParams["start"])
+	reader := multipart.NewReader(bytes.NewReader(body), ctParams["boundary"])
+	var attachments []*Attachment
+	var root *Attachment
+	perField := make(map[string]int)
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			log.Println("failed reading multipart part:", err)
+			break
+		}
+
+		contentID := normalizeContentID(part.Header.Get("Content-ID"))
+		if root == nil && (start == "" || start == contentID) {
+			if root, err = readAttachment(part, 0); err != nil {
+				log.Println("failed reading multipart part:", err)
+				break
+			}
+			continue
+		}
+
+		field := attachmentField(part)
+		perField[field]++
+		attachment, err := c.checkedAttachment(part, field, len(attachments)+1, perField[field])
+		if err != nil {
+			var limitErr *LimitError
+			var uploadErr *UploadError
+			if errors.As(err, &limitErr) || errors.As(err, &uploadErr) {
+				return nil, nil, err
+			}
+			log.Println("failed reading multipart part:", err)
+			break
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if root != nil {
+		decoded, err := c.decodeRoot(root)
+		if err != nil {
+			return nil, nil, err
+		}
+		values = decoded
+	}
+	return values, attachments, nil
+}
+
+// decodeRoot decodes the root part by its content type (json, msgpack or a url encoded form)
+func (c *limitCounter) decodeRoot(root *Attachment) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	mediaType, _, _ := mime.ParseMediaType(root.ContentType)
+	if mediaType == mediaTypeFormURLEncoded {
+		form, err := url.ParseQuery(string(root.content))
+		if err != nil {
+			log.Println("failed decoding root part:", err)
+			return values, nil
+		}
+		if err = c.checkForm(form, nil); err != nil {
+			return nil, err
+		}
+		return formValues(form), nil
+	}
+
+	decodable := decodableMediaType(root.ContentType)
+	if decodable == "" {
+		return values, nil
+	}
+	decoded, err := c.decodePart(string(root.content), decodable)
+	if err != nil {
+		var limitErr *LimitError
+		if errors.As(err, &limitErr) {
+			return nil, err
+		}
+		log.Println("failed decoding root part:", err)
+		return values, nil
+	}
+	if m, ok := decoded.(map[string]interface{}); ok {
+		return m, nil
+	}
+	log.Println("root part is not an object")
+	return values, nil
+}
+
+// attachmentField returns the name used to find the upload policy of an attachment, the form
+// name of the part or else its Content-ID
+func attachmentField(part *multipart.Part) string {
+	if name := part.FormName(); name != "" {
+		return name
+	}
+	return normalizeContentID(part.Header.Get("Content-ID"))
+}
+
+// checkedAttachment reads an attachment enforcing the limits and the upload policy, the content is
+// read at most up to the maximum file size of the policy
+func (c *limitCounter) checkedAttachment(part *multipart.Part, field string, total, fieldCount int) (*Attachment, error) {
+	if err := c.addKeys(1); err != nil {
+		return nil, err
+	}
+	if err := c.checkString(uint64(len(field))); err != nil {
+		return nil, err
+	}
+	if err := c.checkArray(uint64(total)); err != nil {
+		return nil, err
+	}
+
+	uploads := c.limits.Uploads
+	if uploads == nil {
+		return readAttachment(part, 0)
+	}
+	policy := uploads.policyFor(field)
+	uploadErr := &UploadError{Field: field, Filename: part.FileName()}
+	if err := uploads.checkCount(policy, uploadErr, total, fieldCount); err != nil {
+		return nil, err
+	}
+	if err := policy.checkExtension(uploadErr); err != nil {
+		return nil, err
+	}
+	attachment, err := readAttachment(part, policy.MaxFileSize)
+	if err != nil {
+		return nil, err
+	}
+	if err = policy.checkSize(uploadErr, attachment.Size); err != nil {
+		return nil, err
+	}
+	if err = policy.checkType(uploadErr, attachment.content); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// readAttachment reads the part, decoding base64 content, at most maxSize+1 bytes are read if maxSize is set
+func readAttachment(part *multipart.Part, maxSize int64) (*Attachment, error) {
+	var r io.Reader = part
+	if strings.EqualFold(part.Header.Get("Content-Transfer-Encoding"), "base64") {
+		r = base64.NewDecoder(base64.StdEncoding, part)
+	}
+	if maxSize > 0 {
+		r = io.LimitReader(r, maxSize+1)
+	}
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return &Attachment{
+		ContentID:   normalizeContentID(part.Header.Get("Content-ID")),
+		ContentType: part.Header.Get("Content-Type"),
+		Filename:    part.FileName(),
+		Header:      part.Header,
+		Size:        int64(len(content)),
+		content:     content,
+	}, nil
+}
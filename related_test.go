@@ -0,0 +1,240 @@
+package parameters
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// relatedPart is a part of a multipart/related request
+type relatedPart struct {
+	contentID   string
+	contentType string
+	encoding    string
+	filename    string
+	content     []byte
+}
+
+// newRelatedRequest creates a multipart request of the media type with the parts
+func newRelatedRequest(t *testing.T, mediaType, start, target string, parts ...relatedPart) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for _, p := range parts {
+		header := make(textproto.MIMEHeader)
+		if p.contentID != "" {
+			header.Set("Content-ID", "<"+p.contentID+">")
+		}
+		if p.contentType != "" {
+			header.Set("Content-Type", p.contentType)
+		}
+		if p.encoding != "" {
+			header.Set("Content-Transfer-Encoding", p.encoding)
+		}
+		if p.filename != "" {
+			header.Set("Content-Disposition", `attachment; filename="`+p.filename+`"`)
+		}
+		part, err := writer.CreatePart(header)
+		require.NoError(t, err)
+		_, err = part.Write(p.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	contentType := mediaType + `; boundary="` + writer.Boundary() + `"`
+	if start != "" {
+		contentType += `; start="<` + start + `>"`
+	}
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, target, &body)
+	req.Header.Set("Content-Type", contentType)
+	return req
+}
+
+// TestParseParams_MultipartRelated tests parsing a multipart/related request
+func TestParseParams_MultipartRelated(t *testing.T) {
+	req := newRelatedRequest(t, "multipart/related", "", "/documents?version=2",
+		relatedPart{contentID: "root", contentType: "application/json", content: []byte(`{"title":"Contract","scan":"cid:scan-1","version":"1"}`)},
+		relatedPart{contentID: "scan-1", contentType: "image/png", filename: "scan.png", content: testPNG},
+		relatedPart{contentID: "notes", contentType: "text/plain", encoding: "base64", content: []byte(base64.StdEncoding.EncodeToString([]byte("signed copy")))},
+	)
+	params := ParseParams(req)
+	require.NoError(t, params.ParseError())
+
+	assert.Equal(t, "Contract", params.GetString("title"))
+	assert.Equal(t, "1", params.GetString("version"))
+	require.Len(t, params.Attachments(), 2)
+
+	scan, ok := params.GetAttachmentOk(params.GetString("scan"))
+	require.True(t, ok)
+	assert.Equal(t, "scan-1", scan.ContentID)
+	assert.Equal(t, "image/png", scan.ContentType)
+	assert.Equal(t, "scan.png", scan.Filename)
+	assert.Equal(t, int64(len(testPNG)), scan.Size)
+
+	file, err := scan.Open()
+	require.NoError(t, err)
+	content, err := io.ReadAll(file)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+	assert.Equal(t, testPNG, content)
+
+	notes := params.GetAttachment("<notes>")
+	require.NotNil(t, notes)
+	file, err = notes.Open()
+	require.NoError(t, err)
+	content, err = io.ReadAll(file)
+	require.NoError(t, err)
+	assert.Equal(t, "signed copy", string(content))
+
+	_, ok = params.GetAttachmentOk("missing")
+	assert.False(t, ok)
+	_, ok = params.GetAttachmentOk("")
+	assert.False(t, ok)
+}
+
+// TestParseParams_MultipartRelatedStart tests the start parameter of a multipart/related request
+func TestParseParams_MultipartRelatedStart(t *testing.T) {
+	req := newRelatedRequest(t, "multipart/related", "meta", "/",
+		relatedPart{contentID: "file", contentType: "application/pdf", content: []byte("%PDF-1.4")},
+		relatedPart{contentID: "meta", contentType: "application/x-msgpack", content: encodeMsgpack(t, map[string]interface{}{testNameParam: "invoice"})},
+	)
+	params := ParseParams(req)
+
+	assert.Equal(t, "invoice", params.GetString(testNameParam))
+	require.Len(t, params.Attachments(), 1)
+	assert.Equal(t, "file", params.Attachments()[0].ContentID)
+}
+
+// TestParseParams_MultipartMixed tests parsing a multipart/mixed request
+func TestParseParams_MultipartMixed(t *testing.T) {
+	req := newRelatedRequest(t, "multipart/mixed", "", "/",
+		relatedPart{contentType: "application/x-www-form-urlencoded", content: []byte("action=import&dry_run=true")},
+		relatedPart{contentType: "text/csv", content: []byte("a,b\n1,2\n")},
+		relatedPart{contentType: "text/csv", content: []byte("c,d\n3,4\n")},
+	)
+	params := ParseParams(req)
+
+	assert.Equal(t, "import", params.GetString("action"))
+
+	// The root form decodes like a url encoded request
+	form := ParseParams(newLimitsRequest(t, "/", "application/x-www-form-urlencoded", []byte("action=import&dry_run=true")))
+	assert.Equal(t, form.Values, params.Values)
+	assert.IsType(t, true, params.Values["dry_run"])
+	require.Len(t, params.Attachments(), 2)
+	assert.Equal(t, "text/csv", params.Attachments()[1].ContentType)
+	assert.Empty(t, params.Attachments()[1].ContentID)
+}
+
+// TestParseParamsWithLimits_MultipartRelated tests that the limits apply to the root part
+func TestParseParamsWithLimits_MultipartRelated(t *testing.T) {
+	req := newRelatedRequest(t, "multipart/related", "", "/",
+		relatedPart{contentType: "application/json", content: []byte(`{"a":1,"b":2,"c":3}`)},
+	)
+	_, err := ParseParamsWithLimits(req, Limits{MaxKeys: 2})
+
+	var limitErr *LimitError
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, LimitKeys, limitErr.Limit)
+}
+
+// TestParseParamsWithLimits_MultipartRelatedAttachments tests that the limits and the upload policy apply to the attachments
+func TestParseParamsWithLimits_MultipartRelatedAttachments(t *testing.T) {
+	root := relatedPart{contentType: "application/json", content: []byte(`{"title":"Contract"}`)}
+	executables := []relatedPart{root}
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		executables = append(executables, relatedPart{contentID: id, contentType: "application/octet-stream", filename: id + ".exe", content: []byte("MZ")})
+	}
+
+	tests := []struct {
+		name           string
+		parts          []relatedPart
+		limits         Limits
+		expectedLimit  string
+		expectedReason string
+		expectedValue  string
+	}{
+		{
+			name:          "too many keys",
+			parts:         executables,
+			limits:        Limits{MaxKeys: 2},
+			expectedLimit: LimitKeys,
+		},
+		{
+			name:          "too many attachments",
+			parts:         executables,
+			limits:        Limits{MaxArrayLength: 2},
+			expectedLimit: LimitArrayLength,
+		},
+		{
+			name:           "too many files",
+			parts:          executables,
+			limits:         Limits{Uploads: &UploadPolicy{MaxFiles: 1}},
+			expectedReason: UploadTooManyFiles,
+		},
+		{
+			name:           "extension not allowed",
+			parts:          executables,
+			limits:         Limits{Uploads: &UploadPolicy{Default: FilePolicy{AllowedExtensions: []string{".png"}}}},
+			expectedReason: UploadExtensionNotAllowed,
+			expectedValue:  ".exe",
+		},
+		{
+			name: "file too large",
+			parts: []relatedPart{root, {
+				contentID: "scan", contentType: "image/png", encoding: "base64",
+				content: []byte(base64.StdEncoding.EncodeToString(bytes.Repeat([]byte("x"), 100))),
+			}},
+			limits:         Limits{Uploads: &UploadPolicy{Fields: map[string]FilePolicy{"scan": {MaxFileSize: 10}}}},
+			expectedReason: UploadFileTooLarge,
+			expectedValue:  "max 10 bytes",
+		},
+		{
+			name:           "sniffed type not allowed",
+			parts:          []relatedPart{root, {contentID: "scan", filename: "scan.png", content: []byte("<html><body>not an image</body></html>")}},
+			limits:         Limits{Uploads: &UploadPolicy{Default: FilePolicy{AllowedTypes: []string{"image/*"}}}},
+			expectedReason: UploadTypeNotAllowed,
+			expectedValue:  "text/html",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newRelatedRequest(t, "multipart/related", "", "/", tt.parts...)
+			_, err := ParseParamsWithLimits(req, tt.limits)
+			require.Error(t, err)
+
+			if tt.expectedLimit != "" {
+				var limitErr *LimitError
+				require.ErrorAs(t, err, &limitErr)
+				assert.Equal(t, tt.expectedLimit, limitErr.Limit)
+				return
+			}
+			var uploadErr *UploadError
+			require.ErrorAs(t, err, &uploadErr)
+			assert.Equal(t, tt.expectedReason, uploadErr.Reason)
+			assert.Equal(t, tt.expectedValue, uploadErr.Value)
+		})
+	}
+
+	// Attachments that follow the policy are kept
+	req := newRelatedRequest(t, "multipart/mixed", "", "/", root,
+		relatedPart{contentID: "scan", contentType: "image/png", filename: "scan.png", content: testPNG},
+	)
+	params, err := ParseParamsWithLimits(req, Limits{MaxKeys: 2, Uploads: &UploadPolicy{
+		MaxFiles: 1,
+		Default:  FilePolicy{MaxFileSize: 100, AllowedExtensions: []string{".png"}, AllowedTypes: []string{"image/png"}},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "Contract", params.GetString("title"))
+	require.Len(t, params.Attachments(), 1)
+	assert.Equal(t, int64(len(testPNG)), params.GetAttachment("scan").Size)
+}
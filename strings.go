@@ -11,7 +11,7 @@ import (
 // snake_case to camelCase
 //
 //	user_id -> UserID
-var KnownAbbreviations = []string{"id", "json", "html", "xml"}
+var KnownAbbreviations = []string{"id", "json", "html", "xml", "uuid", "ip"}
 
 var camelCaseRe = regexp.MustCompile(`(?:^[\p{Ll}]|\d+|[\p{Lu}]+)[\p{Ll}]*`)
 
@@ -16,6 +16,8 @@ func TestCamelCaseToSnakeCase(t *testing.T) {
 		"MyJSON":      "my_json",
 		"ProfileHTML": "profile_html",
 		"RequestXML":  "request_xml",
+		"AccountUUID": "account_uuid",
+		"ClientIP":    "client_ip",
 	}
 
 	for k, v := range entries {
@@ -36,6 +38,8 @@ func TestSnakeCaseToCamelCase(t *testing.T) {
 		"my_json":      "MyJSON",
 		"profile_html": "ProfileHTML",
 		"request_xml":  "RequestXML",
+		"account_uuid": "AccountUUID",
+		"client_ip":    "ClientIP",
 	}
 
 	for k, v := range entries {
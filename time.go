@@ -0,0 +1,226 @@
+package parameters
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EpochUnit is the unit of numeric (Unix epoch) timestamps
+type EpochUnit int
+
+// The units of epoch timestamps, EpochAuto guesses the unit by the magnitude of the value
+const (
+	EpochAuto EpochUnit = iota
+	EpochSeconds
+	EpochMillis
+	EpochMicros
+	EpochNanos
+)
+
+// timeLayouts are the layouts tried (in order) for timestamps given as strings
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	DateOnly,
+	DateTime,
+	HTMLDateTimeLocal,
+	time.RFC1123,
+	time.RFC1123Z,
+}
+
+// The custom layouts added by RegisterTimeLayout
+var (
+	customLayoutsMu sync.RWMutex
+	customLayouts   []string
+)
+
+// RegisterTimeLayout adds layouts that are tried after the built-in layouts (RFC3339, DateOnly, DateTime,
+// HTMLDateTimeLocal and RFC1123)
+func RegisterTimeLayout(layouts ...string) {
+	customLayoutsMu.Lock()
+	defer customLayoutsMu.Unlock()
+	customLayouts = append(customLayouts, layouts...)
+}
+
+// SetLocation sets the default location of times without a time zone (UTC if not set)
+//
+//	if loc, err := time.LoadLocation(req.Header.Get("X-Timezone")); err == nil {
+//		params.SetLocation(loc)
+//	}
+func (p *Params) SetLocation(loc *time.Location) {
+	p.location = loc
+}
+
+// Location returns the default location of times without a time zone
+func (p *Params) Location() *time.Location {
+	if p.location == nil {
+		return time.UTC
+	}
+	return p.location
+}
+
+// SetEpochUnit makes GetTime accept epoch timestamps (numbers and numeric strings) in the unit, by default
+// only GetEpochTime accepts them
+//
+//	params.SetEpochUnit(parameters.EpochMillis)
+func (p *Params) SetEpochUnit(unit EpochUnit) {
+	p.epochUnit = unit
+	p.epochTimes = true
+}
+
+// unitsPerSecond returns the number of units in a second
+func unitsPerSecond(unit EpochUnit) int64 {
+	switch unit {
+	case EpochSeconds:
+		return 1
+	case EpochMillis:
+		return 1e3
+	case EpochMicros:
+		return 1e6
+	default:
+		return 1e9
+	}
+}
+
+// epochTime converts the number of units since the Unix epoch into a time
+func epochTime(i int64, unit EpochUnit) time.Time {
+	if unit == EpochAuto {
+		unit = guessEpochUnit(float64(i))
+	}
+	perSecond := unitsPerSecond(unit)
+	return time.Unix(i/perSecond, (i%perSecond)*(1e9/perSecond))
+}
+
+// epochTimeFloat converts the (fractional) number of units since the Unix epoch into a time
+func epochTimeFloat(f float64, unit EpochUnit) (time.Time, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return time.Time{}, ErrParse
+	}
+	if unit == EpochAuto {
+		unit = guessEpochUnit(f)
+	}
+	perSecond := unitsPerSecond(unit)
+	sec, frac := math.Modf(f / float64(perSecond))
+	if math.Abs(sec) >= math.MaxInt64 {
+		return time.Time{}, ErrOverflow
+	}
+	return time.Unix(int64(sec), int64(math.Round(frac*1e9))), nil
+}
+
+// guessEpochUnit guesses the unit of an epoch timestamp by its magnitude, seconds are up to the year 5138
+func guessEpochUnit(f float64) EpochUnit {
+	switch abs := math.Abs(f); {
+	case abs < 1e11:
+		return EpochSeconds
+	case abs < 1e14:
+		return EpochMillis
+	case abs < 1e17:
+		return EpochMicros
+	default:
+		return EpochNanos
+	}
+}
+
+// toTime converts the value to a time, numbers are epoch timestamps in the unit if epoch is set
+func toTime(val interface{}, loc *time.Location, unit EpochUnit, epoch bool) (time.Time, error) {
+	switch v := val.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		return parseTime(strings.TrimSpace(v), loc, unit, epoch)
+	case []byte:
+		return parseTime(strings.TrimSpace(string(v)), loc, unit, epoch)
+	case bool:
+		return time.Time{}, ErrWrongType
+	}
+	if !epoch {
+		return time.Time{}, ErrWrongType
+	}
+	if v, ok := val.(json.Number); ok {
+		return parseTime(string(v), loc, unit, epoch)
+	}
+
+	// Integers are exact, other numbers are floats
+	if i, err := toInt64(val); err == nil {
+		return epochTime(i, unit).In(loc), nil
+	}
+	f, err := toFloat64(val)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := epochTimeFloat(f, unit)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return t.In(loc), nil
+}
+
+// parseTime parses a time in one of the layouts, or a numeric timestamp if epoch is set
+func parseTime(s string, loc *time.Location, unit EpochUnit, epoch bool) (time.Time, error) {
+	if epoch {
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return toTime(i, loc, unit, epoch)
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return toTime(f, loc, unit, epoch)
+		}
+	}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	customLayoutsMu.RLock()
+	defer customLayoutsMu.RUnlock()
+	for _, layout := range customLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, ErrParse
+}
+
+// getTime get param by key, return time or a *ConversionError
+func (p *Params) getTime(key string, loc *time.Location, unit EpochUnit, epoch bool) (time.Time, error) {
+	val, err := p.getValue(key, "time.Time")
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := toTime(val, loc, unit, epoch)
+	if err != nil {
+		return time.Time{}, newConversionError(key, val, "time.Time", err)
+	}
+	return t, nil
+}
+
+// GetTimeE get param by key, return time (in the location of the params if the value has no time zone)
+// or a *ConversionError, epoch timestamps are accepted if an epoch unit is set with SetEpochUnit
+func (p *Params) GetTimeE(key string) (time.Time, error) {
+	return p.getTime(key, p.Location(), p.epochUnit, p.epochTimes)
+}
+
+// GetTimeInLocationE get param by key, return time or a *ConversionError
+func (p *Params) GetTimeInLocationE(key string, loc *time.Location) (time.Time, error) {
+	return p.getTime(key, loc, p.epochUnit, p.epochTimes)
+}
+
+// GetEpochTimeE get param by key, return time (numbers and numeric strings are in the unit) or a *ConversionError
+func (p *Params) GetEpochTimeE(key string, unit EpochUnit) (time.Time, error) {
+	return p.getTime(key, p.Location(), unit, true)
+}
+
+// GetEpochTimeOk get param by key, return time (numbers are in the unit)
+func (p *Params) GetEpochTimeOk(key string, unit EpochUnit) (time.Time, bool) {
+	t, err := p.GetEpochTimeE(key, unit)
+	return t, err == nil
+}
+
+// GetEpochTime get param by key, return time (numbers are in the unit)
+func (p *Params) GetEpochTime(key string, unit EpochUnit) time.Time {
+	val, _ := p.GetEpochTimeOk(key, unit)
+	return val
+}
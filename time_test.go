@@ -0,0 +1,126 @@
+package parameters
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParams_GetTimeEpoch tests numeric timestamps with the unit guessed by magnitude (if enabled)
+func TestParams_GetTimeEpoch(t *testing.T) {
+	expected := time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    interface{}
+		expected time.Time
+	}{
+		{"seconds", 1700000000, expected},
+		{"seconds as float", 1700000000.5, expected.Add(500 * time.Millisecond)},
+		{"seconds as uint64", uint64(1700000000), expected},
+		{"seconds as string", "1700000000", expected},
+		{"seconds as json number", json.Number("1700000000"), expected},
+		{"millis", int64(1700000000123), expected.Add(123 * time.Millisecond)},
+		{"millis as float", float64(1700000000123), expected.Add(123 * time.Millisecond)},
+		{"micros", int64(1700000000123456), expected.Add(123456 * time.Microsecond)},
+		{"nanos", int64(1700000000123456789), expected.Add(123456789)},
+		{"negative seconds", -86400, time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := &Params{Values: map[string]interface{}{"at": tt.value}}
+			_, ok := params.GetTimeOk("at")
+			assert.False(t, ok)
+
+			params.SetEpochUnit(EpochAuto)
+			at, err := params.GetTimeE("at")
+			require.NoError(t, err)
+			assert.True(t, tt.expected.Equal(at), "expected %s, got %s", tt.expected, at)
+			assert.Equal(t, time.UTC, at.Location())
+		})
+	}
+}
+
+// TestParams_GetTimeNumericStrings tests that numeric strings are not epoch timestamps by default
+func TestParams_GetTimeNumericStrings(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{"year": "2024", "date": "20240102"}}
+
+	_, err := params.GetTimeE("year")
+	require.ErrorIs(t, err, ErrParse)
+	_, err = params.GetTimeE("date")
+	require.ErrorIs(t, err, ErrParse)
+
+	params.SetEpochUnit(EpochSeconds)
+	assert.Equal(t, int64(2024), params.GetTime("year").Unix())
+}
+
+// TestParams_GetEpochTime tests numeric timestamps with an explicit unit
+func TestParams_GetEpochTime(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{"at": 1700000000, "text": "soon", "string": "1700000000"}}
+
+	assert.Equal(t, int64(1700000000), params.GetEpochTime("at", EpochSeconds).Unix())
+	assert.Equal(t, int64(1700000000), params.GetEpochTime("string", EpochSeconds).Unix())
+	assert.Equal(t, int64(1700000000), params.GetEpochTime("at", EpochAuto).Unix())
+	assert.Equal(t, int64(1700000000), params.GetEpochTime("at", EpochMillis).UnixMilli())
+	assert.Equal(t, int64(1700000000), params.GetEpochTime("at", EpochMicros).UnixMicro())
+	assert.Equal(t, int64(1700000000), params.GetEpochTime("at", EpochNanos).UnixNano())
+
+	_, ok := params.GetEpochTimeOk("text", EpochSeconds)
+	assert.False(t, ok)
+
+	_, err := params.GetEpochTimeE("missing", EpochSeconds)
+	require.ErrorIs(t, err, ErrParamNotFound)
+}
+
+// TestParams_GetTimeLayouts tests the built-in and registered layouts
+func TestParams_GetTimeLayouts(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"nano":     "2024-05-01T10:00:00.123456789Z",
+		"rfc1123":  "Wed, 01 May 2024 10:00:00 GMT",
+		"rfc1123z": "Wed, 01 May 2024 12:00:00 +0200",
+		"custom":   "01/05/2024 10:00",
+	}}
+	expected := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	assert.True(t, expected.Add(123456789).Equal(params.GetTime("nano")))
+	assert.True(t, expected.Equal(params.GetTime("rfc1123")))
+	assert.True(t, expected.Equal(params.GetTime("rfc1123z")))
+
+	_, ok := params.GetTimeOk("custom")
+	assert.False(t, ok)
+
+	RegisterTimeLayout("02/01/2006 15:04")
+	t.Cleanup(func() {
+		customLayoutsMu.Lock()
+		customLayouts = nil
+		customLayoutsMu.Unlock()
+	})
+	assert.True(t, expected.Equal(params.GetTime("custom")))
+}
+
+// TestParams_SetLocation tests the default location of the params
+func TestParams_SetLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	params := &Params{Values: map[string]interface{}{
+		"local": "2024-05-01 12:00:00",
+		"zoned": "2024-05-01T12:00:00Z",
+		"epoch": 1700000000,
+	}}
+	assert.Equal(t, time.UTC, params.Location())
+
+	params.SetLocation(loc)
+	assert.Equal(t, loc, params.Location())
+
+	local := params.GetTime("local")
+	assert.Equal(t, loc, local.Location())
+	assert.True(t, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC).Equal(local))
+
+	zoned := params.GetTime("zoned")
+	assert.Equal(t, 12, zoned.UTC().Hour())
+
+	assert.Equal(t, loc, params.GetEpochTime("epoch", EpochSeconds).Location())
+	assert.Equal(t, loc, params.Clone().Location())
+}
@@ -0,0 +1,215 @@
+package parameters
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// Reasons for rejecting an upload, used in UploadError
+const (
+	UploadTooManyFiles        = "too many files"
+	UploadTooManyFieldFiles   = "too many files for field"
+	UploadFileTooLarge        = "file too large"
+	UploadExtensionNotAllowed = "file extension not allowed"
+	UploadTypeNotAllowed      = "file type not allowed"
+)
+
+// sniffLength is the number of bytes used by http.DetectContentType
+const sniffLength = 512
+
+// ErrUploadRejected is wrapped by every UploadError
+var ErrUploadRejected = errors.New("parameters: upload rejected")
+
+// FilePolicy is the policy for the files uploaded with a single field, a zero value means unlimited
+type FilePolicy struct {
+	// MaxFiles is the maximum number of files for the field
+	MaxFiles int
+
+	// MaxFileSize is the maximum size of a single file in bytes
+	MaxFileSize int64
+
+	// AllowedExtensions are the allowed file name extensions, case-insensitive (".png", "jpg")
+	AllowedExtensions []string
+
+	// AllowedTypes are the allowed MIME types detected from the file content ("image/png", "image/*")
+	AllowedTypes []string
+}
+
+// UploadPolicy is the policy enforced on multipart/form-data file uploads while the parts are streamed,
+// and on the attachments of multipart/related and multipart/mixed requests (found by form name or Content-ID)
+type UploadPolicy struct {
+	// MaxFiles is the maximum number of files in the request
+	MaxFiles int
+
+	// Default is the policy for fields that are not found in Fields
+	Default FilePolicy
+
+	// Fields are the policies by field name
+	Fields map[string]FilePolicy
+}
+
+// UploadError is returned when an uploaded file violates the UploadPolicy
+type UploadError struct {
+	// Field is the form field of the offending file
+	Field string
+
+	// Filename is the name of the offending file
+	Filename string
+
+	// Reason is why the file was rejected (UploadTooManyFiles, UploadFileTooLarge, ...)
+	Reason string
+
+	// Value is the rejected value, such as the extension or the detected content type
+	Value string
+}
+
+// Error returns the error message
+func (e *UploadError) Error() string {
+	msg := fmt.Sprintf("parameters: upload %q rejected for field %q: %s", e.Filename, e.Field, e.Reason)
+	if e.Value != "" {
+		msg += " (" + e.Value + ")"
+	}
+	return msg
+}
+
+// Unwrap returns ErrUploadRejected
+func (e *UploadError) Unwrap() error {
+	return ErrUploadRejected
+}
+
+// policyFor returns the policy for the field
+func (u *UploadPolicy) policyFor(field string) FilePolicy {
+	if policy, ok := u.Fields[field]; ok {
+		return policy
+	}
+	return u.Default
+}
+
+// checkPart enforces the policy on a single file part
+func (u *UploadPolicy) checkPart(part *multipart.Part, total, fieldCount int) error {
+	field := part.FormName()
+	policy := u.policyFor(field)
+	uploadErr := &UploadError{Field: field, Filename: part.FileName()}
+	if err := u.checkCount(policy, uploadErr, total, fieldCount); err != nil {
+		return err
+	}
+	if err := policy.checkExtension(uploadErr); err != nil {
+		return err
+	}
+
+	sniff := make([]byte, sniffLength)
+	n, err := io.ReadFull(part, sniff)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return err
+	}
+	if err = policy.checkType(uploadErr, sniff[:n]); err != nil {
+		return err
+	}
+
+	if policy.MaxFileSize > 0 {
+		size := int64(n)
+		if size <= policy.MaxFileSize {
+			var rest int64
+			if rest, err = io.Copy(io.Discard, io.LimitReader(part, policy.MaxFileSize-size+1)); err != nil {
+				return err
+			}
+			size += rest
+		}
+		return policy.checkSize(uploadErr, size)
+	}
+	return nil
+}
+
+// checkCount enforces the number of files in the request and for the field
+func (u *UploadPolicy) checkCount(policy FilePolicy, uploadErr *UploadError, total, fieldCount int) error {
+	if u.MaxFiles > 0 && total > u.MaxFiles {
+		uploadErr.Reason = UploadTooManyFiles
+		return uploadErr
+	}
+	if policy.MaxFiles > 0 && fieldCount > policy.MaxFiles {
+		uploadErr.Reason = UploadTooManyFieldFiles
+		return uploadErr
+	}
+	return nil
+}
+
+// checkExtension enforces the allowed extensions on the file name
+func (p FilePolicy) checkExtension(uploadErr *UploadError) error {
+	if len(p.AllowedExtensions) == 0 {
+		return nil
+	}
+	ext := strings.ToLower(filepath.Ext(uploadErr.Filename))
+	if !allowedExtension(p.AllowedExtensions, ext) {
+		uploadErr.Reason = UploadExtensionNotAllowed
+		uploadErr.Value = ext
+		return uploadErr
+	}
+	return nil
+}
+
+// checkType enforces the allowed types on the type detected from the first bytes of the content
+func (p FilePolicy) checkType(uploadErr *UploadError, content []byte) error {
+	if len(p.AllowedTypes) == 0 {
+		return nil
+	}
+	detected := detectMediaType(content[:min(len(content), sniffLength)])
+	if !allowedType(p.AllowedTypes, detected) {
+		uploadErr.Reason = UploadTypeNotAllowed
+		uploadErr.Value = detected
+		return uploadErr
+	}
+	return nil
+}
+
+// checkSize enforces the maximum file size
+func (p FilePolicy) checkSize(uploadErr *UploadError, size int64) error {
+	if p.MaxFileSize > 0 && size > p.MaxFileSize {
+		uploadErr.Reason = UploadFileTooLarge
+		uploadErr.Value = fmt.Sprintf("max %d bytes", p.MaxFileSize)
+		return uploadErr
+	}
+	return nil
+}
+
+// detectMediaType detects the media type of the content, without parameters
+func detectMediaType(content []byte) string {
+	detected := http.DetectContentType(content)
+	if mediaType, _, err := mime.ParseMediaType(detected); err == nil {
+		return mediaType
+	}
+	return detected
+}
+
+// allowedExtension returns true if the extension is in the list, with or without a leading dot
+func allowedExtension(allowed []string, ext string) bool {
+	for _, a := range allowed {
+		a = strings.ToLower(a)
+		if !strings.HasPrefix(a, ".") {
+			a = "." + a
+		}
+		if a == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// allowedType returns true if the media type matches the list, "image/*" matches any image type
+func allowedType(allowed []string, mediaType string) bool {
+	for _, a := range allowed {
+		a = strings.ToLower(a)
+		if a == mediaType || a == "*/*" {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(a, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,249 @@
+package parameters
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPNG is the start of a png file
+var testPNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// testFile is a file to add to a multipart request
+type testFile struct {
+	field    string
+	filename string
+	content  []byte
+}
+
+// newMultipartRequest creates a multipart/form-data request with the values and files
+func newMultipartRequest(t *testing.T, values map[string]string, files ...testFile) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for k, v := range values {
+		require.NoError(t, writer.WriteField(k, v))
+	}
+	for _, f := range files {
+		part, err := writer.CreateFormFile(f.field, f.filename)
+		require.NoError(t, err)
+		_, err = part.Write(f.content)
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/upload", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+// TestParseParamsWithLimits_Uploads tests the upload policies
+func TestParseParamsWithLimits_Uploads(t *testing.T) {
+	imagePolicy := FilePolicy{
+		MaxFiles:          2,
+		MaxFileSize:       1024,
+		AllowedExtensions: []string{"png", ".JPG"},
+		AllowedTypes:      []string{"image/*"},
+	}
+
+	tests := []struct {
+		name           string
+		policy         *UploadPolicy
+		files          []testFile
+		expectedField  string
+		expectedReason string
+		expectedValue  string
+	}{
+		{
+			name:   "too many files overall",
+			policy: &UploadPolicy{MaxFiles: 1},
+			files: []testFile{
+				{field: "a", filename: "a.txt", content: []byte("a")},
+				{field: "b", filename: "b.txt", content: []byte("b")},
+			},
+			expectedField:  "b",
+			expectedReason: UploadTooManyFiles,
+		},
+		{
+			name:   "too many files for a field",
+			policy: &UploadPolicy{Fields: map[string]FilePolicy{"avatar": imagePolicy}},
+			files: []testFile{
+				{field: "avatar", filename: "1.png", content: testPNG},
+				{field: "avatar", filename: "2.png", content: testPNG},
+				{field: "avatar", filename: "3.png", content: testPNG},
+			},
+			expectedField:  "avatar",
+			expectedReason: UploadTooManyFieldFiles,
+		},
+		{
+			name:   "file too large",
+			policy: &UploadPolicy{Default: FilePolicy{MaxFileSize: 10}},
+			files: []testFile{
+				{field: "doc", filename: "doc.txt", content: []byte(strings.Repeat("x", 11))},
+			},
+			expectedField:  "doc",
+			expectedReason: UploadFileTooLarge,
+			expectedValue:  "max 10 bytes",
+		},
+		{
+			name:   "file larger than the sniff length",
+			policy: &UploadPolicy{Default: FilePolicy{MaxFileSize: 600}},
+			files: []testFile{
+				{field: "doc", filename: "doc.txt", content: []byte(strings.Repeat("x", 601))},
+			},
+			expectedField:  "doc",
+			expectedReason: UploadFileTooLarge,
+			expectedValue:  "max 600 bytes",
+		},
+		{
+			name:   "extension not allowed",
+			policy: &UploadPolicy{Fields: map[string]FilePolicy{"avatar": imagePolicy}},
+			files: []testFile{
+				{field: "avatar", filename: "avatar.exe", content: testPNG},
+			},
+			expectedField:  "avatar",
+			expectedReason: UploadExtensionNotAllowed,
+			expectedValue:  ".exe",
+		},
+		{
+			name:   "sniffed type not allowed",
+			policy: &UploadPolicy{Fields: map[string]FilePolicy{"avatar": imagePolicy}},
+			files: []testFile{
+				{field: "avatar", filename: "avatar.png", content: []byte("<html><body>not an image</body></html>")},
+			},
+			expectedField:  "avatar",
+			expectedReason: UploadTypeNotAllowed,
+			expectedValue:  "text/html",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := newMultipartRequest(t, map[string]string{testNameParam: "Alice"}, tt.files...)
+			params, err := ParseParamsWithLimits(req, Limits{Uploads: tt.policy})
+			require.Error(t, err)
+			require.ErrorIs(t, err, ErrUploadRejected)
+
+			var uploadErr *UploadError
+			require.ErrorAs(t, err, &uploadErr)
+			assert.Equal(t, tt.expectedField, uploadErr.Field)
+			assert.Equal(t, tt.expectedReason, uploadErr.Reason)
+			assert.Equal(t, tt.expectedValue, uploadErr.Value)
+			assert.Contains(t, err.Error(), tt.expectedField)
+			assert.Empty(t, params.Values)
+		})
+	}
+}
+
+// TestParseParamsWithLimits_UploadsAllowed tests uploads that follow the policy
+func TestParseParamsWithLimits_UploadsAllowed(t *testing.T) {
+	policy := &UploadPolicy{
+		MaxFiles: 3,
+		Default:  FilePolicy{MaxFileSize: 100, AllowedTypes: []string{"text/plain"}},
+		Fields: map[string]FilePolicy{
+			"avatar": {MaxFiles: 1, AllowedExtensions: []string{".png"}, AllowedTypes: []string{"image/png"}},
+		},
+	}
+	req := newMultipartRequest(t, map[string]string{testNameParam: "Alice"},
+		testFile{field: "avatar", filename: "me.PNG", content: testPNG},
+		testFile{field: "notes", filename: "notes.txt", content: []byte("hello world")},
+	)
+
+	params, err := ParseParamsWithLimits(req, Limits{Uploads: policy})
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", params.GetString(testNameParam))
+
+	avatar, ok := params.GetFileOk("avatar")
+	require.True(t, ok)
+	require.NotNil(t, avatar)
+	assert.Equal(t, "me.PNG", avatar.Filename)
+
+	notes, ok := params.GetFileOk("notes")
+	require.True(t, ok)
+	file, err := notes.Open()
+	require.NoError(t, err)
+	defer func() { _ = file.Close() }()
+	content := make([]byte, notes.Size)
+	_, err = file.Read(content)
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(content))
+}
+
+// TestParseParamsWithLimits_UploadsBodySize tests the body size limit together with an upload policy
+func TestParseParamsWithLimits_UploadsBodySize(t *testing.T) {
+	req := newMultipartRequest(t, nil, testFile{field: "doc", filename: "doc.txt", content: bytes.Repeat([]byte("x"), 2048)})
+	_, err := ParseParamsWithLimits(req, Limits{MaxBodySize: 1024, Uploads: &UploadPolicy{}})
+
+	var limitErr *LimitError
+	require.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, LimitBodySize, limitErr.Limit)
+}
+
+// byteCounter counts the bytes read
+type byteCounter struct {
+	io.Reader
+	n int64
+}
+
+// Read reads and counts the bytes
+func (r *byteCounter) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// TestParseParamsWithLimits_UploadsStreamed tests that a violation stops reading the body
+func TestParseParamsWithLimits_UploadsStreamed(t *testing.T) {
+	var head bytes.Buffer
+	writer := multipart.NewWriter(&head)
+	part, err := writer.CreateFormFile("avatar", "avatar.exe")
+	require.NoError(t, err)
+	_, err = part.Write(testPNG)
+	require.NoError(t, err)
+	_, err = writer.CreateFormFile("video", "video.png")
+	require.NoError(t, err)
+
+	// The second file is far larger than the memory used for the form
+	const size = 64 << 20
+	body := &byteCounter{Reader: io.MultiReader(
+		&head,
+		io.LimitReader(zeroReader{}, size),
+		strings.NewReader("\r\n--"+writer.Boundary()+"--\r\n"),
+	)}
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/upload", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	_, err = ParseParamsWithLimits(req, Limits{Uploads: &UploadPolicy{
+		Default: FilePolicy{AllowedExtensions: []string{".png"}},
+	}})
+	var uploadErr *UploadError
+	require.ErrorAs(t, err, &uploadErr)
+	assert.Equal(t, UploadExtensionNotAllowed, uploadErr.Reason)
+	assert.Less(t, body.n, int64(1<<20))
+}
+
+// zeroReader reads zeros
+type zeroReader struct{}
+
+// Read fills p with zeros
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
+
+// TestAllowedType tests the allowedType function
+func TestAllowedType(t *testing.T) {
+	assert.True(t, allowedType([]string{"image/png"}, "image/png"))
+	assert.True(t, allowedType([]string{"IMAGE/*"}, "image/gif"))
+	assert.True(t, allowedType([]string{"*/*"}, "application/pdf"))
+	assert.False(t, allowedType([]string{"image/*"}, "imagex/gif"))
+	assert.False(t, allowedType([]string{"image/png"}, "text/plain"))
+}
@@ -0,0 +1,175 @@
+package parameters
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// uuidLength is the length of a canonical UUID ("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx")
+const uuidLength = 36
+
+// Errors returned when parsing a UUID
+var (
+	// ErrInvalidUUID is returned when the value is not a UUID
+	ErrInvalidUUID = errors.New("parameters: invalid UUID")
+
+	// ErrUUIDVersion is the reason when the UUID is not of one of the required versions
+	ErrUUIDVersion = errors.New("parameters: unexpected UUID version")
+)
+
+// UUID is a universally unique identifier (RFC 9562)
+type UUID [16]byte
+
+// NilUUID is the UUID with all bits set to zero
+var NilUUID UUID
+
+// ParseUUID parses a UUID in the canonical ("6ba7b810-9dad-11d1-80b4-00c04fd430c8"), braced
+// ("{6ba7b810-...}") or URN ("urn:uuid:6ba7b810-...") form
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	s = strings.TrimSpace(s)
+	if len(s) == uuidLength+2 && s[0] == '{' && s[len(s)-1] == '}' {
+		s = s[1 : len(s)-1]
+	} else if len(s) == uuidLength+9 && strings.EqualFold(s[:9], "urn:uuid:") {
+		s = s[9:]
+	}
+	if len(s) != uuidLength || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, fmt.Errorf("%w: %q", ErrInvalidUUID, s)
+	}
+
+	digits := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	if _, err := hex.Decode(u[:], []byte(digits)); err != nil {
+		return NilUUID, fmt.Errorf("%w: %q", ErrInvalidUUID, s)
+	}
+	return u, nil
+}
+
+// String returns the UUID in the canonical form
+func (u UUID) String() string {
+	buf := make([]byte, uuidLength)
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf)
+}
+
+// Version returns the version of the UUID (e.g. 4 or 7)
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// IsNil returns true if all the bits of the UUID are zero
+func (u UUID) IsNil() bool {
+	return u == NilUUID
+}
+
+// MarshalText encodes the UUID in the canonical form
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText decodes a UUID in one of the forms accepted by ParseUUID
+func (u *UUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// uuidTarget returns the target type name for the versions
+func uuidTarget(versions []int) string {
+	if len(versions) == 0 {
+		return "UUID"
+	}
+	names := make([]string, len(versions))
+	for i, v := range versions {
+		names[i] = strconv.Itoa(v)
+	}
+	return "UUID (version " + strings.Join(names, " or ") + ")"
+}
+
+// convertUUID converts the value to a UUID of one of the versions (any version if none)
+func convertUUID(key string, val interface{}, versions []int) (UUID, error) {
+	var u UUID
+	switch v := val.(type) {
+	case UUID:
+		u = v
+	case [16]byte:
+		u = v
+	case []byte:
+		if len(v) != len(u) {
+			return convertUUID(key, string(v), versions)
+		}
+		copy(u[:], v)
+	case string:
+		parsed, err := ParseUUID(v)
+		if err != nil {
+			return NilUUID, newConversionError(key, val, uuidTarget(versions), ErrParse)
+		}
+		u = parsed
+	default:
+		return NilUUID, newConversionError(key, val, uuidTarget(versions), ErrWrongType)
+	}
+
+	if len(versions) == 0 {
+		return u, nil
+	}
+	for _, version := range versions {
+		if u.Version() == version {
+			return u, nil
+		}
+	}
+	return NilUUID, newConversionError(key, val, uuidTarget(versions), ErrUUIDVersion)
+}
+
+// GetUUIDE get param by key, return UUID (of one of the versions, if any) or a *ConversionError
+func (p *Params) GetUUIDE(key string, versions ...int) (UUID, error) {
+	val, err := p.getValue(key, uuidTarget(versions))
+	if err != nil {
+		return NilUUID, err
+	}
+	return convertUUID(key, val, versions)
+}
+
+// GetUUIDOk get param by key, return UUID (of one of the versions, if any)
+//
+//	id, ok := params.GetUUIDOk("id", 4, 7)
+func (p *Params) GetUUIDOk(key string, versions ...int) (UUID, bool) {
+	val, err := p.GetUUIDE(key, versions...)
+	return val, err == nil
+}
+
+// GetUUID get param by key, return UUID (of one of the versions, if any)
+func (p *Params) GetUUID(key string, versions ...int) UUID {
+	val, _ := p.GetUUIDOk(key, versions...)
+	return val
+}
+
+// GetUUIDSliceE get param by key, return slice of UUIDs (of one of the versions, if any) or a *ConversionError
+func (p *Params) GetUUIDSliceE(key string, versions ...int) ([]UUID, error) {
+	return getSlice(p, key, "[]"+uuidTarget(versions), func(elemKey string, val interface{}) (UUID, error) {
+		return convertUUID(elemKey, val, versions)
+	})
+}
+
+// GetUUIDSliceOk get param by key, return slice of UUIDs (of one of the versions, if any)
+func (p *Params) GetUUIDSliceOk(key string, versions ...int) ([]UUID, bool) {
+	return sliceOk(p.GetUUIDSliceE(key, versions...))
+}
+
+// GetUUIDSlice get param by key, return slice of UUIDs (of one of the versions, if any)
+func (p *Params) GetUUIDSlice(key string, versions ...int) []UUID {
+	val, _ := p.GetUUIDSliceOk(key, versions...)
+	return val
+}
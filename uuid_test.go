@@ -0,0 +1,164 @@
+package parameters
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test UUIDs of different versions
+const (
+	testUUIDv1 = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	testUUIDv4 = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	testUUIDv7 = "0190163d-8694-739b-aea5-966c26f8ad91"
+)
+
+// TestParseUUID tests parsing the forms of a UUID
+func TestParseUUID(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		ok    bool
+	}{
+		{"canonical", testUUIDv4, true},
+		{"upper case", "F47AC10B-58CC-4372-A567-0E02B2C3D479", true},
+		{"braced", "{" + testUUIDv4 + "}", true},
+		{"urn", "urn:uuid:" + testUUIDv4, true},
+		{"urn upper case", "URN:UUID:" + testUUIDv4, true},
+		{"surrounding whitespace", " " + testUUIDv4 + " ", true},
+		{"without dashes", "f47ac10b58cc4372a5670e02b2c3d479", false},
+		{"misplaced dashes", "f47ac10b5-8cc-4372-a567-0e02b2c3d479", false},
+		{"invalid characters", "g47ac10b-58cc-4372-a567-0e02b2c3d479", false},
+		{"unbalanced brace", "{" + testUUIDv4, false},
+		{"empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := ParseUUID(tt.value)
+			if !tt.ok {
+				require.ErrorIs(t, err, ErrInvalidUUID)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testUUIDv4, u.String())
+			assert.Equal(t, 4, u.Version())
+		})
+	}
+
+	assert.True(t, NilUUID.IsNil())
+	assert.Equal(t, "00000000-0000-0000-0000-000000000000", NilUUID.String())
+}
+
+// TestUUID_Text tests the text encoding of a UUID
+func TestUUID_Text(t *testing.T) {
+	var u UUID
+	require.NoError(t, u.UnmarshalText([]byte("{"+testUUIDv7+"}")))
+	text, err := u.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, testUUIDv7, string(text))
+
+	require.ErrorIs(t, u.UnmarshalText([]byte("nope")), ErrInvalidUUID)
+}
+
+// TestParams_GetUUID tests the UUID getters
+func TestParams_GetUUID(t *testing.T) {
+	raw, err := ParseUUID(testUUIDv4)
+	require.NoError(t, err)
+
+	params := &Params{Values: map[string]interface{}{
+		"v1":     testUUIDv1,
+		"v4":     testUUIDv4,
+		"v7":     "urn:uuid:" + testUUIDv7,
+		"binary": raw[:],
+		"typed":  raw,
+		"text":   "abc",
+		"number": 42,
+	}}
+
+	u, ok := params.GetUUIDOk("v4")
+	require.True(t, ok)
+	assert.Equal(t, testUUIDv4, u.String())
+	assert.Equal(t, raw, params.GetUUID("binary"))
+	assert.Equal(t, raw, params.GetUUID("typed"))
+
+	u, ok = params.GetUUIDOk("v7", 4, 7)
+	require.True(t, ok)
+	assert.Equal(t, 7, u.Version())
+
+	_, err = params.GetUUIDE("v1", 4, 7)
+	require.ErrorIs(t, err, ErrUUIDVersion)
+	assert.Equal(t, "v1 must be a valid UUID (version 4 or 7)", err.Error())
+
+	_, err = params.GetUUIDE("text")
+	require.ErrorIs(t, err, ErrParse)
+	assert.Equal(t, "text must be a UUID", err.Error())
+
+	_, err = params.GetUUIDE("number")
+	require.ErrorIs(t, err, ErrWrongType)
+
+	_, ok = params.GetUUIDOk("missing")
+	assert.False(t, ok)
+	assert.True(t, params.GetUUID("missing").IsNil())
+}
+
+// TestParams_GetUUIDSlice tests the UUID slice getters
+func TestParams_GetUUIDSlice(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"ids":   []interface{}{testUUIDv4, "{" + testUUIDv7 + "}"},
+		"csv":   testUUIDv4 + ", " + testUUIDv7,
+		"mixed": []interface{}{testUUIDv4, testUUIDv1},
+	}}
+
+	ids, ok := params.GetUUIDSliceOk("ids")
+	require.True(t, ok)
+	require.Len(t, ids, 2)
+	assert.Equal(t, testUUIDv7, ids[1].String())
+	assert.Equal(t, ids, params.GetUUIDSlice("csv"))
+
+	assert.Len(t, params.GetUUIDSlice("mixed"), 2)
+	_, err := params.GetUUIDSliceE("mixed", 4)
+	require.ErrorIs(t, err, ErrUUIDVersion)
+
+	var convErr *ConversionError
+	require.ErrorAs(t, err, &convErr)
+	assert.Equal(t, "mixed.1", convErr.Key)
+}
+
+// TestParams_GetUUIDPathParam tests UUID path parameters
+func TestParams_GetUUIDPathParam(t *testing.T) {
+	handler := func(_ http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		u, ok := GetParams(r).GetUUIDOk("user_id", 4)
+		require.True(t, ok)
+		assert.Equal(t, testUUIDv4, u.String())
+	}
+
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodGet, "https://example.com", nil)
+	MakeHTTPRouterParsedReq(handler)(httptest.NewRecorder(), req, httprouter.Params{
+		httprouter.Param{Key: "user_id", Value: testUUIDv4},
+	})
+}
+
+// TestImbue_UUID tests that Imbue sets UUID fields
+func TestImbue_UUID(t *testing.T) {
+	params := &Params{Values: map[string]interface{}{
+		"account_uuid": testUUIDv4,
+		"members":      []interface{}{testUUIDv7},
+	}}
+
+	type testType struct {
+		AccountUUID UUID
+		Members     []UUID
+	}
+
+	var obj testType
+	params.Imbue(&obj)
+
+	assert.Equal(t, testUUIDv4, obj.AccountUUID.String())
+	require.Len(t, obj.Members, 1)
+	assert.Equal(t, testUUIDv7, obj.Members[0].String())
+}